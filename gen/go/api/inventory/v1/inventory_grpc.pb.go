@@ -20,12 +20,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Inventory_CreateProduct_FullMethodName = "/api.inventory.v1.Inventory/CreateProduct"
-	Inventory_GetProduct_FullMethodName    = "/api.inventory.v1.Inventory/GetProduct"
-	Inventory_ListProducts_FullMethodName  = "/api.inventory.v1.Inventory/ListProducts"
-	Inventory_UpdateProduct_FullMethodName = "/api.inventory.v1.Inventory/UpdateProduct"
-	Inventory_DeleteProduct_FullMethodName = "/api.inventory.v1.Inventory/DeleteProduct"
-	Inventory_UpdateStock_FullMethodName   = "/api.inventory.v1.Inventory/UpdateStock"
+	Inventory_CreateProduct_FullMethodName         = "/api.inventory.v1.Inventory/CreateProduct"
+	Inventory_GetProduct_FullMethodName            = "/api.inventory.v1.Inventory/GetProduct"
+	Inventory_GetProductBySku_FullMethodName       = "/api.inventory.v1.Inventory/GetProductBySku"
+	Inventory_GetProductByCatalogId_FullMethodName = "/api.inventory.v1.Inventory/GetProductByCatalogId"
+	Inventory_ListProducts_FullMethodName          = "/api.inventory.v1.Inventory/ListProducts"
+	Inventory_ListLowStockProducts_FullMethodName  = "/api.inventory.v1.Inventory/ListLowStockProducts"
+	Inventory_UpdateProduct_FullMethodName         = "/api.inventory.v1.Inventory/UpdateProduct"
+	Inventory_DeleteProduct_FullMethodName         = "/api.inventory.v1.Inventory/DeleteProduct"
+	Inventory_RestoreProduct_FullMethodName        = "/api.inventory.v1.Inventory/RestoreProduct"
+	Inventory_UpdateStock_FullMethodName           = "/api.inventory.v1.Inventory/UpdateStock"
+	Inventory_BatchUpdateStock_FullMethodName      = "/api.inventory.v1.Inventory/BatchUpdateStock"
+	Inventory_ReserveStock_FullMethodName          = "/api.inventory.v1.Inventory/ReserveStock"
+	Inventory_CommitReservation_FullMethodName     = "/api.inventory.v1.Inventory/CommitReservation"
+	Inventory_ReleaseReservation_FullMethodName    = "/api.inventory.v1.Inventory/ReleaseReservation"
+	Inventory_ListStockMovements_FullMethodName    = "/api.inventory.v1.Inventory/ListStockMovements"
+	Inventory_TransferStock_FullMethodName         = "/api.inventory.v1.Inventory/TransferStock"
+	Inventory_CreateLocation_FullMethodName        = "/api.inventory.v1.Inventory/CreateLocation"
+	Inventory_ListLocations_FullMethodName         = "/api.inventory.v1.Inventory/ListLocations"
+	Inventory_CreateVariant_FullMethodName         = "/api.inventory.v1.Inventory/CreateVariant"
+	Inventory_ListVariants_FullMethodName          = "/api.inventory.v1.Inventory/ListVariants"
+	Inventory_ImportProducts_FullMethodName        = "/api.inventory.v1.Inventory/ImportProducts"
+	Inventory_ExportProducts_FullMethodName        = "/api.inventory.v1.Inventory/ExportProducts"
 )
 
 // InventoryClient is the client API for Inventory service.
@@ -38,14 +54,48 @@ type InventoryClient interface {
 	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	// ดึงข้อมูลสินค้า
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
+	// ค้นหาสินค้าจาก SKU (เช่น จากเครื่องสแกนบาร์โค้ด)
+	GetProductBySku(ctx context.Context, in *GetProductBySkuRequest, opts ...grpc.CallOption) (*Product, error)
+	// ค้นหาสินค้าจากรหัสสินค้าใน catalog (product service)
+	GetProductByCatalogId(ctx context.Context, in *GetProductByCatalogIdRequest, opts ...grpc.CallOption) (*Product, error)
 	// ดึงรายการสินค้าทั้งหมด
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	// ดึงรายการสินค้าที่สต็อกต่ำกว่าหรือเท่ากับจุดสั่งซื้อ (reorder threshold)
+	ListLowStockProducts(ctx context.Context, in *ListLowStockProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
 	// อัพเดทสินค้า
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error)
-	// ลบสินค้า
+	// ลบสินค้า (soft delete; ข้อมูลจะถูกลบถาวรเมื่อพ้นระยะเวลาเก็บรักษา)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// กู้คืนสินค้าที่ถูกลบ (ก่อนถูกลบถาวร)
+	RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error)
 	// อัพเดทจำนวนสต็อก
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*Product, error)
+	// อัพเดทสต็อกหลายรายการใน transaction เดียว (ล้มเหลวทั้งชุดถ้ามีรายการใดผิดพลาด)
+	BatchUpdateStock(ctx context.Context, in *BatchUpdateStockRequest, opts ...grpc.CallOption) (*BatchUpdateStockResponse, error)
+	// จองสต็อกสินค้า (หมดอายุอัตโนมัติถ้าไม่ commit ภายใน TTL)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// ยืนยันการจอง และตัดสต็อกจริง
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// ยกเลิกการจอง และคืนสต็อกที่จองไว้
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// ดูประวัติการเคลื่อนไหวของสต็อก (ledger) รวมถึงสินค้าที่ถูกลบหรือ purge ไปแล้ว
+	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
+	// ย้ายสต็อกระหว่างคลัง (atomic; stock รวมไม่เปลี่ยน)
+	TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*Product, error)
+	// สร้างคลังสินค้า/สถานที่เก็บสินค้าใหม่
+	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*Location, error)
+	// ดึงรายการคลังสินค้าทั้งหมด
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	// สร้างสินค้าย่อย (variant) เช่น ไซซ์/สี ภายใต้สินค้าหลัก
+	CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*Product, error)
+	// ดึงรายการสินค้าย่อยของสินค้าหลัก
+	ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error)
+	// นำเข้าสินค้าจากไฟล์ CSV หรือ NDJSON (upsert ตาม SKU) แบบ client streaming
+	// ผ่าน HTTP ใช้ POST /v1/products:import แบบ multipart/form-data (field "file")
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error)
+	// ส่งออกสินค้าเป็นไฟล์ CSV หรือ NDJSON แบบ server streaming
+	// ผ่าน HTTP ใช้ GET /v1/products:export?format=csv
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error)
 }
 
 type inventoryClient struct {
@@ -76,6 +126,26 @@ func (c *inventoryClient) GetProduct(ctx context.Context, in *GetProductRequest,
 	return out, nil
 }
 
+func (c *inventoryClient) GetProductBySku(ctx context.Context, in *GetProductBySkuRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, Inventory_GetProductBySku_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetProductByCatalogId(ctx context.Context, in *GetProductByCatalogIdRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, Inventory_GetProductByCatalogId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProductsResponse)
@@ -86,6 +156,16 @@ func (c *inventoryClient) ListProducts(ctx context.Context, in *ListProductsRequ
 	return out, nil
 }
 
+func (c *inventoryClient) ListLowStockProducts(ctx context.Context, in *ListLowStockProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListLowStockProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryClient) UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Product)
@@ -106,6 +186,16 @@ func (c *inventoryClient) DeleteProduct(ctx context.Context, in *DeleteProductRe
 	return out, nil
 }
 
+func (c *inventoryClient) RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, Inventory_RestoreProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryClient) UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*Product, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Product)
@@ -116,6 +206,138 @@ func (c *inventoryClient) UpdateStock(ctx context.Context, in *UpdateStockReques
 	return out, nil
 }
 
+func (c *inventoryClient) BatchUpdateStock(ctx context.Context, in *BatchUpdateStockRequest, opts ...grpc.CallOption) (*BatchUpdateStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateStockResponse)
+	err := c.cc.Invoke(ctx, Inventory_BatchUpdateStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, Inventory_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, Inventory_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, Inventory_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockMovementsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListStockMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, Inventory_TransferStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*Location, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Location)
+	err := c.cc.Invoke(ctx, Inventory_CreateLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, Inventory_CreateVariant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (*ListVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVariantsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Inventory_ServiceDesc.Streams[0], Inventory_ImportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportProductsRequest, ImportProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_ImportProductsClient = grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse]
+
+func (c *inventoryClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Inventory_ServiceDesc.Streams[1], Inventory_ExportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProductsRequest, ExportProductsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_ExportProductsClient = grpc.ServerStreamingClient[ExportProductsResponse]
+
 // InventoryServer is the server API for Inventory service.
 // All implementations must embed UnimplementedInventoryServer
 // for forward compatibility.
@@ -126,14 +348,48 @@ type InventoryServer interface {
 	CreateProduct(context.Context, *CreateProductRequest) (*Product, error)
 	// ดึงข้อมูลสินค้า
 	GetProduct(context.Context, *GetProductRequest) (*Product, error)
+	// ค้นหาสินค้าจาก SKU (เช่น จากเครื่องสแกนบาร์โค้ด)
+	GetProductBySku(context.Context, *GetProductBySkuRequest) (*Product, error)
+	// ค้นหาสินค้าจากรหัสสินค้าใน catalog (product service)
+	GetProductByCatalogId(context.Context, *GetProductByCatalogIdRequest) (*Product, error)
 	// ดึงรายการสินค้าทั้งหมด
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	// ดึงรายการสินค้าที่สต็อกต่ำกว่าหรือเท่ากับจุดสั่งซื้อ (reorder threshold)
+	ListLowStockProducts(context.Context, *ListLowStockProductsRequest) (*ListProductsResponse, error)
 	// อัพเดทสินค้า
 	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
-	// ลบสินค้า
+	// ลบสินค้า (soft delete; ข้อมูลจะถูกลบถาวรเมื่อพ้นระยะเวลาเก็บรักษา)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
+	// กู้คืนสินค้าที่ถูกลบ (ก่อนถูกลบถาวร)
+	RestoreProduct(context.Context, *RestoreProductRequest) (*Product, error)
 	// อัพเดทจำนวนสต็อก
 	UpdateStock(context.Context, *UpdateStockRequest) (*Product, error)
+	// อัพเดทสต็อกหลายรายการใน transaction เดียว (ล้มเหลวทั้งชุดถ้ามีรายการใดผิดพลาด)
+	BatchUpdateStock(context.Context, *BatchUpdateStockRequest) (*BatchUpdateStockResponse, error)
+	// จองสต็อกสินค้า (หมดอายุอัตโนมัติถ้าไม่ commit ภายใน TTL)
+	ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error)
+	// ยืนยันการจอง และตัดสต็อกจริง
+	CommitReservation(context.Context, *CommitReservationRequest) (*Reservation, error)
+	// ยกเลิกการจอง และคืนสต็อกที่จองไว้
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*Reservation, error)
+	// ดูประวัติการเคลื่อนไหวของสต็อก (ledger) รวมถึงสินค้าที่ถูกลบหรือ purge ไปแล้ว
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
+	// ย้ายสต็อกระหว่างคลัง (atomic; stock รวมไม่เปลี่ยน)
+	TransferStock(context.Context, *TransferStockRequest) (*Product, error)
+	// สร้างคลังสินค้า/สถานที่เก็บสินค้าใหม่
+	CreateLocation(context.Context, *CreateLocationRequest) (*Location, error)
+	// ดึงรายการคลังสินค้าทั้งหมด
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	// สร้างสินค้าย่อย (variant) เช่น ไซซ์/สี ภายใต้สินค้าหลัก
+	CreateVariant(context.Context, *CreateVariantRequest) (*Product, error)
+	// ดึงรายการสินค้าย่อยของสินค้าหลัก
+	ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error)
+	// นำเข้าสินค้าจากไฟล์ CSV หรือ NDJSON (upsert ตาม SKU) แบบ client streaming
+	// ผ่าน HTTP ใช้ POST /v1/products:import แบบ multipart/form-data (field "file")
+	ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error
+	// ส่งออกสินค้าเป็นไฟล์ CSV หรือ NDJSON แบบ server streaming
+	// ผ่าน HTTP ใช้ GET /v1/products:export?format=csv
+	ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error
 	mustEmbedUnimplementedInventoryServer()
 }
 
@@ -150,18 +406,66 @@ func (UnimplementedInventoryServer) CreateProduct(context.Context, *CreateProduc
 func (UnimplementedInventoryServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProduct not implemented")
 }
+func (UnimplementedInventoryServer) GetProductBySku(context.Context, *GetProductBySkuRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductBySku not implemented")
+}
+func (UnimplementedInventoryServer) GetProductByCatalogId(context.Context, *GetProductByCatalogIdRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductByCatalogId not implemented")
+}
 func (UnimplementedInventoryServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListProducts not implemented")
 }
+func (UnimplementedInventoryServer) ListLowStockProducts(context.Context, *ListLowStockProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLowStockProducts not implemented")
+}
 func (UnimplementedInventoryServer) UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateProduct not implemented")
 }
 func (UnimplementedInventoryServer) DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteProduct not implemented")
 }
+func (UnimplementedInventoryServer) RestoreProduct(context.Context, *RestoreProductRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreProduct not implemented")
+}
 func (UnimplementedInventoryServer) UpdateStock(context.Context, *UpdateStockRequest) (*Product, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateStock not implemented")
 }
+func (UnimplementedInventoryServer) BatchUpdateStock(context.Context, *BatchUpdateStockRequest) (*BatchUpdateStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateStock not implemented")
+}
+func (UnimplementedInventoryServer) ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedInventoryServer) CommitReservation(context.Context, *CommitReservationRequest) (*Reservation, error) {
+	return nil, status.Error(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedInventoryServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*Reservation, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedInventoryServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStockMovements not implemented")
+}
+func (UnimplementedInventoryServer) TransferStock(context.Context, *TransferStockRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferStock not implemented")
+}
+func (UnimplementedInventoryServer) CreateLocation(context.Context, *CreateLocationRequest) (*Location, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateLocation not implemented")
+}
+func (UnimplementedInventoryServer) ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLocations not implemented")
+}
+func (UnimplementedInventoryServer) CreateVariant(context.Context, *CreateVariantRequest) (*Product, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (UnimplementedInventoryServer) ListVariants(context.Context, *ListVariantsRequest) (*ListVariantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVariants not implemented")
+}
+func (UnimplementedInventoryServer) ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedInventoryServer) ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportProducts not implemented")
+}
 func (UnimplementedInventoryServer) mustEmbedUnimplementedInventoryServer() {}
 func (UnimplementedInventoryServer) testEmbeddedByValue()                   {}
 
@@ -219,6 +523,42 @@ func _Inventory_GetProduct_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_GetProductBySku_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductBySkuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetProductBySku(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetProductBySku_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetProductBySku(ctx, req.(*GetProductBySkuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetProductByCatalogId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByCatalogIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetProductByCatalogId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetProductByCatalogId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetProductByCatalogId(ctx, req.(*GetProductByCatalogIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Inventory_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProductsRequest)
 	if err := dec(in); err != nil {
@@ -237,6 +577,24 @@ func _Inventory_ListProducts_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_ListLowStockProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListLowStockProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListLowStockProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListLowStockProducts(ctx, req.(*ListLowStockProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Inventory_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateProductRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +631,24 @@ func _Inventory_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_RestoreProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).RestoreProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_RestoreProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).RestoreProduct(ctx, req.(*RestoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Inventory_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateStockRequest)
 	if err := dec(in); err != nil {
@@ -291,6 +667,204 @@ func _Inventory_UpdateStock_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_BatchUpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).BatchUpdateStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_BatchUpdateStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).BatchUpdateStock(ctx, req.(*BatchUpdateStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListStockMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_TransferStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).TransferStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_TransferStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).TransferStock(ctx, req.(*TransferStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateLocation(ctx, req.(*CreateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListLocations(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateVariant(ctx, req.(*CreateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListVariants(ctx, req.(*ListVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InventoryServer).ImportProducts(&grpc.GenericServerStream[ImportProductsRequest, ImportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_ImportProductsServer = grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]
+
+func _Inventory_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServer).ExportProducts(m, &grpc.GenericServerStream[ExportProductsRequest, ExportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_ExportProductsServer = grpc.ServerStreamingServer[ExportProductsResponse]
+
 // Inventory_ServiceDesc is the grpc.ServiceDesc for Inventory service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -306,10 +880,22 @@ var Inventory_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _Inventory_GetProduct_Handler,
 		},
+		{
+			MethodName: "GetProductBySku",
+			Handler:    _Inventory_GetProductBySku_Handler,
+		},
+		{
+			MethodName: "GetProductByCatalogId",
+			Handler:    _Inventory_GetProductByCatalogId_Handler,
+		},
 		{
 			MethodName: "ListProducts",
 			Handler:    _Inventory_ListProducts_Handler,
 		},
+		{
+			MethodName: "ListLowStockProducts",
+			Handler:    _Inventory_ListLowStockProducts_Handler,
+		},
 		{
 			MethodName: "UpdateProduct",
 			Handler:    _Inventory_UpdateProduct_Handler,
@@ -318,11 +904,66 @@ var Inventory_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProduct",
 			Handler:    _Inventory_DeleteProduct_Handler,
 		},
+		{
+			MethodName: "RestoreProduct",
+			Handler:    _Inventory_RestoreProduct_Handler,
+		},
 		{
 			MethodName: "UpdateStock",
 			Handler:    _Inventory_UpdateStock_Handler,
 		},
+		{
+			MethodName: "BatchUpdateStock",
+			Handler:    _Inventory_BatchUpdateStock_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _Inventory_ReserveStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _Inventory_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _Inventory_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "ListStockMovements",
+			Handler:    _Inventory_ListStockMovements_Handler,
+		},
+		{
+			MethodName: "TransferStock",
+			Handler:    _Inventory_TransferStock_Handler,
+		},
+		{
+			MethodName: "CreateLocation",
+			Handler:    _Inventory_CreateLocation_Handler,
+		},
+		{
+			MethodName: "ListLocations",
+			Handler:    _Inventory_ListLocations_Handler,
+		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _Inventory_CreateVariant_Handler,
+		},
+		{
+			MethodName: "ListVariants",
+			Handler:    _Inventory_ListVariants_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _Inventory_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _Inventory_ExportProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory/v1/inventory.proto",
 }
@@ -0,0 +1,67 @@
+// Package auth authenticates requests carrying an access token (JWT) issued
+// by the user service.
+//
+// Server validates the "Authorization: Bearer <token>" header on both HTTP
+// and gRPC and stores the token's Claims in the request context, where
+// handlers read them with FromContext. Client forwards the caller's token
+// on outgoing calls to other services.
+package auth
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+var (
+	// ErrMissingToken is returned when a protected operation is called without a bearer token.
+	ErrMissingToken = errors.Unauthorized(common.ErrorCode_UNAUTHENTICATED.String(), "missing bearer token")
+	// ErrInvalidToken is returned for a malformed, expired or wrongly signed token.
+	ErrInvalidToken = errors.Unauthorized(common.ErrorCode_UNAUTHENTICATED.String(), "invalid access token")
+)
+
+// Claims are the claims of an access token. The subject is the user id.
+type Claims struct {
+	jwt.RegisteredClaims
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// UserID returns the id of the authenticated user.
+func (c *Claims) UserID() string {
+	return c.Subject
+}
+
+type contextKey struct{}
+
+type authInfo struct {
+	claims *Claims
+	token  string
+}
+
+// NewContext returns a copy of ctx carrying the claims and the raw token
+// they were parsed from.
+func NewContext(ctx context.Context, claims *Claims, token string) context.Context {
+	return context.WithValue(ctx, contextKey{}, &authInfo{claims: claims, token: token})
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	info, ok := ctx.Value(contextKey{}).(*authInfo)
+	if !ok {
+		return nil, false
+	}
+	return info.claims, true
+}
+
+// TokenFromContext returns the raw access token of the authenticated caller.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	info, ok := ctx.Value(contextKey{}).(*authInfo)
+	if !ok {
+		return "", false
+	}
+	return info.token, true
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	authpb "github.com/reverny/kratos-mono/gen/go/api/auth"
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+// Roles carried in Claims.Role. A role is granted everything a lower one is.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleUser:  1,
+	RoleAdmin: 2,
+}
+
+// HasRole reports whether role satisfies required. An empty required role
+// is satisfied by any role; an unknown role satisfies nothing else.
+func HasRole(role, required string) bool {
+	if required == "" {
+		return true
+	}
+	return roleRanks[role] >= roleRanks[required]
+}
+
+// Policy maps operations, e.g. "/api.user.v1.User/DeleteUser", to the role
+// they require. Operations missing from it are open to every caller that
+// passed authentication.
+type Policy map[string]string
+
+// NewPolicy reads the (api.auth.required_role) option of every method of
+// the services declared in files.
+func NewPolicy(files ...protoreflect.FileDescriptor) (Policy, error) {
+	p := make(Policy)
+	for _, fd := range files {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			methods := sd.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				md := methods.Get(j)
+				role, _ := proto.GetExtension(md.Options(), authpb.E_RequiredRole).(string)
+				if role == "" {
+					continue
+				}
+				if _, ok := roleRanks[role]; !ok {
+					return nil, fmt.Errorf("%s: unknown required_role %q", md.FullName(), role)
+				}
+				p[fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())] = role
+			}
+		}
+	}
+	return p, nil
+}
+
+// MustPolicy is like NewPolicy but panics on an unknown role.
+func MustPolicy(files ...protoreflect.FileDescriptor) Policy {
+	p, err := NewPolicy(files...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Allowed reports whether a caller with role may call operation.
+func (p Policy) Allowed(operation, role string) bool {
+	return HasRole(role, p[operation])
+}
+
+// Authorize returns a middleware that rejects callers whose role is below
+// the one the policy requires for the operation. It reads the Claims put
+// into the context by Server, so it must run after it.
+func Authorize(p Policy) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			required := p[tr.Operation()]
+			if required == "" {
+				return handler(ctx, req)
+			}
+			claims, ok := FromContext(ctx)
+			if !ok {
+				return nil, ErrMissingToken
+			}
+			if !HasRole(claims.Role, required) {
+				return nil, errors.Forbidden(common.ErrorCode_PERMISSION_DENIED.String(),
+					fmt.Sprintf("role %q is required", required))
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Middleware authenticates the caller with Server and then enforces p with
+// Authorize, the chain every service installs after recovery.
+func Middleware(v *Verifier, p Policy, opts ...Option) middleware.Middleware {
+	return middleware.Chain(Server(v, opts...), Authorize(p))
+}
@@ -0,0 +1,120 @@
+// Package authztest checks an authorization policy against a table of
+// expected decisions, so that a change to a (api.auth.required_role) option
+// shows up as a failing case:
+//
+//	// services/user/internal/server/auth_test.go
+//	func TestPolicy(t *testing.T) {
+//		authztest.Run(t, policy, []authztest.Case{
+//			{Operation: v1.User_DeleteUser_FullMethodName, Role: auth.RoleUser, Allowed: false},
+//			{Operation: v1.User_DeleteUser_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+//		})
+//	}
+package authztest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/pkg/auth"
+)
+
+// Case is one row of a policy table. An empty Role stands for a caller
+// without a token.
+type Case struct {
+	Operation string
+	Role      string
+	Allowed   bool
+}
+
+func (c Case) String() string {
+	role := c.Role
+	if role == "" {
+		role = "anonymous"
+	}
+	return fmt.Sprintf("%s as %s", c.Operation, role)
+}
+
+// TB is the part of testing.TB used by Run.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Run reports every case whose outcome differs from Check's.
+func Run(t TB, p auth.Policy, cases []Case) {
+	t.Helper()
+	for _, err := range Check(p, cases) {
+		t.Errorf("%v", err)
+	}
+}
+
+// Check sends each case through auth.Authorize and returns an error for
+// every case that was not decided as expected. A denied caller must get
+// PERMISSION_DENIED, or UNAUTHENTICATED when it has no token.
+func Check(p auth.Policy, cases []Case) []error {
+	authorize := auth.Authorize(p)
+	var errs []error
+	for _, c := range cases {
+		called := false
+		handler := authorize(func(context.Context, interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+
+		ctx := transport.NewServerContext(context.Background(), &fakeTransport{operation: c.Operation})
+		if c.Role != "" {
+			ctx = auth.NewContext(ctx, &auth.Claims{Role: c.Role}, "")
+		}
+		_, err := handler(ctx, nil)
+
+		switch {
+		case c.Allowed && (err != nil || !called):
+			errs = append(errs, fmt.Errorf("%v: want allowed, got %v", c, err))
+		case !c.Allowed && err == nil:
+			errs = append(errs, fmt.Errorf("%v: want denied, got allowed", c))
+		case !c.Allowed && errors.Reason(err) != wantReason(c):
+			errs = append(errs, fmt.Errorf("%v: want %s, got %v", c, wantReason(c), err))
+		}
+	}
+	return errs
+}
+
+func wantReason(c Case) string {
+	if c.Role == "" {
+		return common.ErrorCode_UNAUTHENTICATED.String()
+	}
+	return common.ErrorCode_PERMISSION_DENIED.String()
+}
+
+type fakeTransport struct {
+	operation string
+}
+
+func (t *fakeTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *fakeTransport) Endpoint() string                { return "" }
+func (t *fakeTransport) Operation() string               { return t.operation }
+func (t *fakeTransport) RequestHeader() transport.Header { return header{} }
+func (t *fakeTransport) ReplyHeader() transport.Header   { return header{} }
+
+type header map[string][]string
+
+func (h header) Get(key string) string {
+	if v := h[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+func (h header) Set(key, value string)      { h[key] = []string{value} }
+func (h header) Add(key, value string)      { h[key] = append(h[key], value) }
+func (h header) Values(key string) []string { return h[key] }
+func (h header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultRefreshInterval is how long fetched keys are used before the
+	// key set is fetched again.
+	DefaultRefreshInterval = 10 * time.Minute
+	// minRefreshInterval limits how often a token with an unknown kid can
+	// trigger a fetch.
+	minRefreshInterval = 30 * time.Second
+	fetchTimeout       = 5 * time.Second
+	maxJWKSBytes       = 1 << 20
+)
+
+// RemoteKeySet is a jwt.Keyfunc source backed by a JWKS URL. Keys are fetched
+// on first use, refetched after the refresh interval, and refetched early
+// when a token names a kid that is not cached, e.g. right after the issuer
+// rotated its keys.
+//
+// Fetches run outside the lock and concurrent ones are merged, so a slow
+// issuer only delays the tokens whose key is not cached yet.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	fetches         singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+}
+
+// NewRemoteKeySet returns a key set fetched from url. A non-positive
+// refreshInterval means DefaultRefreshInterval.
+func NewRemoteKeySet(url string, refreshInterval time.Duration) *RemoteKeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	return &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: fetchTimeout},
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Keyfunc returns the key named by the token's "kid" header. A token
+// without a kid is accepted only while the set holds a single key.
+func (s *RemoteKeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	s.mu.Lock()
+	key, found := s.lookup(kid)
+	stale := time.Since(s.fetchedAt) >= s.refreshInterval
+	due := time.Since(s.lastAttempt) >= minRefreshInterval
+	s.mu.Unlock()
+
+	if found {
+		if stale && due {
+			// Keep serving the cached key while the set is refreshed.
+			go s.refresh()
+		}
+		return key, nil
+	}
+
+	if err := s.refresh(); err != nil && err != errRefreshTooSoon {
+		return nil, err
+	}
+	s.mu.Lock()
+	key, found = s.lookup(kid)
+	s.mu.Unlock()
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// errRefreshTooSoon is returned by refresh within minRefreshInterval of the
+// previous attempt.
+var errRefreshTooSoon = errors.New("jwks refreshed too recently")
+
+// refresh fetches the key set, joining a fetch that is already running.
+// A failed fetch leaves the cached keys in place.
+func (s *RemoteKeySet) refresh() error {
+	_, err, _ := s.fetches.Do(s.url, func() (any, error) {
+		s.mu.Lock()
+		if time.Since(s.lastAttempt) < minRefreshInterval {
+			s.mu.Unlock()
+			return nil, errRefreshTooSoon
+		}
+		s.lastAttempt = time.Now()
+		s.mu.Unlock()
+
+		keys, err := s.fetch()
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.keys, s.fetchedAt = keys, time.Now()
+		s.mu.Unlock()
+		return nil, nil
+	})
+	return err
+}
+
+func (s *RemoteKeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *RemoteKeySet) fetch() (map[string]crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: %s", resp.Status)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.PublicKey()
+		if err != nil {
+			// One unsupported key must not hide the others.
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRemoteKeySetFetchesOutsideTheLock(t *testing.T) {
+	newJWK := func(kid string) JWK {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwk, err := NewJWK(kid, &priv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jwk
+	}
+	keyA, keyB := newJWK("a"), newJWK("b")
+
+	var (
+		fetches atomic.Int32
+		block   = make(chan struct{})
+		started = make(chan struct{}, 1)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		set := JWKS{Keys: []JWK{keyA}}
+		if fetches.Add(1) > 1 {
+			started <- struct{}{}
+			<-block
+			set.Keys = append(set.Keys, keyB)
+		}
+		json.NewEncoder(w).Encode(set)
+	}))
+	defer srv.Close()
+
+	s := NewRemoteKeySet(srv.URL, time.Hour)
+	tokenFor := func(kid string) *jwt.Token {
+		return &jwt.Token{Header: map[string]any{"kid": kid}}
+	}
+	if _, err := s.Keyfunc(tokenFor("a")); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+
+	// Let the next unknown kid fetch again, then hold that fetch open.
+	s.mu.Lock()
+	s.lastAttempt = time.Time{}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Keyfunc(tokenFor("b"))
+			errs <- err
+		}()
+	}
+	<-started
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Keyfunc(tokenFor("a"))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("cached key: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cached key lookup waited for the fetch")
+	}
+
+	close(block)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("rotated key: %v", err)
+		}
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("fetched %d times, want 2", n)
+	}
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc"
+)
+
+const (
+	authorizationKey = "Authorization"
+	bearerPrefix     = "Bearer "
+)
+
+// Option configures Server.
+type Option func(*options)
+
+type options struct {
+	public map[string]bool
+}
+
+// WithPublicOperations lets the operations, e.g. "/api.user.v1.User/Login",
+// through without a token. A valid token sent to them is still honoured.
+func WithPublicOperations(operations ...string) Option {
+	return func(o *options) {
+		for _, op := range operations {
+			o.public[op] = true
+		}
+	}
+}
+
+// Server returns a middleware that requires a valid bearer token on every
+// operation not made public, and puts its Claims into the context.
+func Server(v *Verifier, opts ...Option) middleware.Middleware {
+	o := &options{public: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, ErrMissingToken
+			}
+			public := o.public[tr.Operation()]
+
+			token, found := bearerToken(tr.RequestHeader().Get(authorizationKey))
+			if !found {
+				if public {
+					return handler(ctx, req)
+				}
+				return nil, ErrMissingToken
+			}
+			claims, err := v.Verify(token)
+			if err != nil {
+				if public {
+					return handler(ctx, req)
+				}
+				return nil, err
+			}
+			return handler(NewContext(ctx, claims, token), req)
+		}
+	}
+}
+
+// Client returns a middleware that forwards the caller's access token, so
+// that a downstream service authorizes the same user.
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if token, ok := TokenFromContext(ctx); ok {
+				if tr, ok := transport.FromClientContext(ctx); ok {
+					tr.RequestHeader().Set(authorizationKey, bearerPrefix+token)
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// StreamServerInterceptor runs m once when a gRPC stream opens, as kratos
+// applies grpc.Middleware to unary calls only. The stream handler sees the
+// context m passes on, so FromContext works inside it as well.
+func StreamServerInterceptor(m middleware.Middleware) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := m(func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return nil, handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})(ss.Context(), nil)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func bearerToken(header string) (string, bool) {
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// leeway tolerates clock skew between the issuer and the verifying service.
+const leeway = 30 * time.Second
+
+// Verifier parses and validates access tokens.
+type Verifier struct {
+	keyFunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// NewVerifier returns a Verifier that checks signatures with the key returned
+// by keyFunc. Non-empty issuer and audience must match the "iss" and "aud"
+// claims.
+func NewVerifier(keyFunc jwt.Keyfunc, issuer, audience string) *Verifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return &Verifier{keyFunc: keyFunc, parser: jwt.NewParser(opts...)}
+}
+
+// Config is the server.auth section of a service's config; the generated
+// conf.Server_Auth message of every service implements it.
+type Config interface {
+	GetIssuer() string
+	GetAudience() string
+	GetPublicKey() string
+	GetJwksUrl() string
+	GetJwksRefreshInterval() *durationpb.Duration
+}
+
+// NewVerifierFromConfig returns a Verifier for a service that does not issue
+// tokens itself. Keys come from the user service's JWKS, or from a fixed
+// public key when no JWKS URL is configured.
+func NewVerifierFromConfig(c Config) (*Verifier, error) {
+	if url := c.GetJwksUrl(); url != "" {
+		keys := NewRemoteKeySet(url, c.GetJwksRefreshInterval().AsDuration())
+		return NewVerifier(keys.Keyfunc, c.GetIssuer(), c.GetAudience()), nil
+	}
+	key, err := ParsePublicKey(c.GetPublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("server.auth.public_key: %w", err)
+	}
+	return NewVerifier(StaticKey(key), c.GetIssuer(), c.GetAudience()), nil
+}
+
+// Verify returns the claims of token, or ErrInvalidToken.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims Claims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.keyFunc); err != nil {
+		return nil, ErrInvalidToken.WithCause(err)
+	}
+	if claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// StaticKey returns a jwt.Keyfunc that always returns key, whatever the
+// token's kid.
+func StaticKey(key crypto.PublicKey) jwt.Keyfunc {
+	return func(*jwt.Token) (any, error) {
+		return key, nil
+	}
+}
+
+// ParsePublicKey decodes a PEM "PUBLIC KEY" (PKIX) block holding an RSA or
+// ECDSA key.
+func ParsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PEM public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,38 @@
+// Package errcode builds errors whose gRPC status agrees with their
+// common.ErrorCode reason where the HTTP status alone cannot express it.
+//
+// Kratos derives an error's gRPC code from its HTTP code, so every 409
+// Conflict becomes codes.Aborted. That is right for the ABORTED reason
+// (version conflicts, concurrent writes) but not for ALREADY_EXISTS.
+package errcode
+
+import (
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+// AlreadyExists returns a 409 Conflict error with the ALREADY_EXISTS reason
+// that gRPC reports as codes.AlreadyExists. errors.Is, errors.Reason and
+// errors.IsConflict treat it as the underlying Kratos error.
+func AlreadyExists(message string) error {
+	return &alreadyExists{errors.Conflict(common.ErrorCode_ALREADY_EXISTS.String(), message)}
+}
+
+type alreadyExists struct {
+	err *errors.Error
+}
+
+func (e *alreadyExists) Error() string { return e.err.Error() }
+
+func (e *alreadyExists) Unwrap() error { return e.err }
+
+// GRPCStatus keeps the message and details of the Kratos status and
+// replaces its code.
+func (e *alreadyExists) GRPCStatus() *status.Status {
+	p := e.err.GRPCStatus().Proto()
+	p.Code = int32(codes.AlreadyExists)
+	return status.FromProto(p)
+}
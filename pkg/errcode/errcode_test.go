@@ -0,0 +1,39 @@
+package errcode
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+func TestAlreadyExists(t *testing.T) {
+	errExists := AlreadyExists("sku already exists")
+	err := fmt.Errorf("create product: %w", errExists)
+
+	if !errors.Is(err, errExists) || !errors.IsConflict(err) || errors.Reason(err) != common.ErrorCode_ALREADY_EXISTS.String() {
+		t.Fatalf("%v does not read as a 409 ALREADY_EXISTS error", err)
+	}
+	if !errors.Is(err, AlreadyExists("username already exists")) {
+		t.Fatal("errors with the same code and reason should match, as Kratos errors do")
+	}
+	if errors.Is(err, errors.Conflict(common.ErrorCode_ABORTED.String(), "conflict")) {
+		t.Fatal("matched an ABORTED conflict")
+	}
+
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.AlreadyExists {
+		t.Fatalf("gRPC status of the wrapped error %v", s)
+	}
+	s, ok := status.FromError(errExists)
+	if !ok || s.Code() != codes.AlreadyExists || s.Message() != "sku already exists" {
+		t.Fatalf("gRPC status %v", s)
+	}
+	// A client decoding the status sees the same Kratos error.
+	if got := errors.FromError(s.Err()); got.Code != 409 || got.Reason != common.ErrorCode_ALREADY_EXISTS.String() {
+		t.Fatalf("client decoded %v", got)
+	}
+}
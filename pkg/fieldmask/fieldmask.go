@@ -0,0 +1,48 @@
+// Package fieldmask checks the google.protobuf.FieldMask update_mask sent to
+// the Update RPCs against the fields a resource allows clients to change.
+package fieldmask
+
+import (
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+// Validate returns paths without duplicates, or an INVALID_ARGUMENT error
+// naming the first path that is not in allowed. An empty result means the
+// request carried no mask; callers decide what that selects.
+func Validate(paths []string, allowed ...string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	valid := make(map[string]bool, len(allowed))
+	for _, p := range allowed {
+		valid[p] = true
+	}
+
+	seen := make(map[string]bool, len(paths))
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if !valid[p] {
+			return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("invalid update_mask path %q", p))
+		}
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// Has reports whether paths selects path.
+func Has(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
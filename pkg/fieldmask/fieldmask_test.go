@@ -0,0 +1,45 @@
+package fieldmask
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+func TestValidate(t *testing.T) {
+	allowed := []string{"name", "description", "price"}
+	cases := []struct {
+		name  string
+		paths []string
+		want  []string
+		ok    bool
+	}{
+		{"no mask", nil, nil, true},
+		{"empty mask", []string{}, nil, true},
+		{"allowed paths", []string{"price", "name"}, []string{"price", "name"}, true},
+		{"duplicates", []string{"name", "price", "name"}, []string{"name", "price"}, true},
+		{"unknown path", []string{"name", "stock"}, nil, false},
+		{"case differs", []string{"Name"}, nil, false},
+		{"empty path", []string{""}, nil, false},
+		{"nested path", []string{"price.units"}, nil, false},
+	}
+	for _, c := range cases {
+		got, err := Validate(c.paths, allowed...)
+		if c.ok && (err != nil || !slices.Equal(got, c.want)) {
+			t.Errorf("%s: Validate = %v, %v; want %v", c.name, got, err, c.want)
+		}
+		if !c.ok && (got != nil || errors.Reason(err) != common.ErrorCode_INVALID_ARGUMENT.String()) {
+			t.Errorf("%s: Validate = %v, %v; want INVALID_ARGUMENT", c.name, got, err)
+		}
+	}
+}
+
+func TestHas(t *testing.T) {
+	paths := []string{"name", "price"}
+	if !Has(paths, "price") || Has(paths, "description") || Has(nil, "name") {
+		t.Fatal("Has does not match the listed paths")
+	}
+}
@@ -0,0 +1,193 @@
+// Package idempotency provides a server middleware that deduplicates retried
+// requests carrying an idempotency key.
+//
+// The first successful reply for a key is stored and replayed to every
+// duplicate within the configured window. Keys are scoped per operation and
+// per authenticated caller (see auth.FromContext), so callers cannot read
+// each other's replies by guessing a key, and reusing a key with a different
+// request payload is rejected. Failed requests
+// are not stored so the client may retry them with the same key. A reply
+// that cannot be stored is still returned; its key is released and logged,
+// so a retry runs the request again.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/pkg/auth"
+)
+
+const (
+	// HeaderKey carries the idempotency key: the HTTP header, or the
+	// gRPC metadata key "idempotency-key".
+	HeaderKey = "Idempotency-Key"
+	// ReplayedHeader is set on replies served from the store.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultTTL is how long replies are kept when no window is configured.
+	DefaultTTL = 24 * time.Hour
+
+	maxKeyLength = 255
+)
+
+var (
+	// ErrKeyTooLong is returned for keys longer than 255 bytes.
+	ErrKeyTooLong = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "idempotency key is too long")
+	// ErrKeyReused is returned when a key is replayed with a different request.
+	ErrKeyReused = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "idempotency key was used with a different request")
+	// ErrInProgress is returned while the first request for a key is still running.
+	ErrInProgress = errors.Conflict(common.ErrorCode_ABORTED.String(), "a request with this idempotency key is in progress")
+)
+
+// Record is a completed request stored under its key.
+type Record struct {
+	// Fingerprint is a digest of the request payload.
+	Fingerprint []byte
+	Reply       proto.Message
+}
+
+// Store keeps idempotency records. Implementations must be safe for
+// concurrent use and claim keys atomically.
+type Store interface {
+	// Begin claims key for ttl. It returns the stored record when the key
+	// has completed, a nil record when the caller now holds the claim, or
+	// ErrInProgress when another request holds it.
+	Begin(ctx context.Context, key string, ttl time.Duration) (*Record, error)
+	// Commit stores the record for a claimed key for ttl.
+	Commit(ctx context.Context, key string, rec *Record, ttl time.Duration) error
+	// Abort releases a claimed key without storing a record.
+	Abort(ctx context.Context, key string) error
+}
+
+// Option is idempotency option.
+type Option func(*options)
+
+type options struct {
+	store  Store
+	ttl    time.Duration
+	logger log.Logger
+}
+
+// WithStore sets the record store. The default is an in-memory store private
+// to the middleware.
+func WithStore(s Store) Option {
+	return func(o *options) {
+		o.store = s
+	}
+}
+
+// WithTTL sets how long replies are replayed. Non-positive values keep DefaultTTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		if ttl > 0 {
+			o.ttl = ttl
+		}
+	}
+}
+
+// WithLogger sets the logger for records that could not be stored. The
+// default is the global logger.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Server is a server middleware that replays the stored reply for requests
+// whose idempotency key was already served. Requests without a key pass
+// through untouched; combine with selector to limit it to mutating operations.
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{ttl: DefaultTTL, logger: log.GetLogger()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.store == nil {
+		o.store = NewMemoryStore()
+	}
+	helper := log.NewHelper(o.logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			key := tr.RequestHeader().Get(HeaderKey)
+			if key == "" {
+				return handler(ctx, req)
+			}
+			if len(key) > maxKeyLength {
+				return nil, ErrKeyTooLong
+			}
+			key = tr.Operation() + "\x00" + caller(ctx) + "\x00" + key
+			fp := fingerprint(req)
+
+			rec, err := o.store.Begin(ctx, key, o.ttl)
+			if err != nil {
+				return nil, err
+			}
+			if rec != nil {
+				if string(rec.Fingerprint) != string(fp) {
+					return nil, ErrKeyReused
+				}
+				tr.ReplyHeader().Set(ReplayedHeader, "true")
+				return proto.Clone(rec.Reply), nil
+			}
+
+			committed := false
+			defer func() {
+				// Covers handler errors and panics alike.
+				if !committed {
+					_ = o.store.Abort(ctx, key)
+				}
+			}()
+
+			reply, err = handler(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			msg, ok := reply.(proto.Message)
+			if !ok {
+				return reply, nil
+			}
+			// The handler's work is done; failing the request now would
+			// invite a retry that repeats it under a released key anyway.
+			if err := o.store.Commit(ctx, key, &Record{Fingerprint: fp, Reply: proto.Clone(msg)}, o.ttl); err != nil {
+				helper.WithContext(ctx).Errorf("idempotency: store reply of %s: %v", tr.Operation(), err)
+				return reply, nil
+			}
+			committed = true
+			return reply, nil
+		}
+	}
+}
+
+// caller returns the subject of the access token, or "" for anonymous
+// requests. The middleware must run after auth.Server to see it.
+func caller(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.UserID()
+	}
+	return ""
+}
+
+func fingerprint(req interface{}) []byte {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
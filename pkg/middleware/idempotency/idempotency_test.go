@@ -0,0 +1,118 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/reverny/kratos-mono/pkg/auth"
+)
+
+type testTransport struct {
+	request, reply http.Header
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/api.test.v1.Test/Create" }
+func (t *testTransport) RequestHeader() transport.Header { return headerCarrier(t.request) }
+func (t *testTransport) ReplyHeader() transport.Header   { return headerCarrier(t.reply) }
+
+type headerCarrier http.Header
+
+func (h headerCarrier) Get(key string) string      { return http.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestKeysAreScopedPerCaller(t *testing.T) {
+	calls := 0
+	handler := Server()(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		calls++
+		claims, _ := auth.FromContext(ctx)
+		return wrapperspb.String("reply for " + claims.UserID()), nil
+	})
+	call := func(user string) (string, bool) {
+		tr := &testTransport{request: http.Header{}, reply: http.Header{}}
+		tr.request.Set(HeaderKey, "key-1")
+		ctx := transport.NewServerContext(context.Background(), tr)
+		ctx = auth.NewContext(ctx, &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: user}}, "")
+		reply, err := handler(ctx, wrapperspb.String("same request"))
+		if err != nil {
+			t.Fatalf("%s: %v", user, err)
+		}
+		return reply.(*wrapperspb.StringValue).Value, tr.reply.Get(ReplayedHeader) == "true"
+	}
+
+	if got, replayed := call("alice"); got != "reply for alice" || replayed {
+		t.Fatalf("alice: got %q (replayed %v)", got, replayed)
+	}
+	if got, replayed := call("bob"); got != "reply for bob" || replayed {
+		t.Fatalf("bob reusing alice's key: got %q (replayed %v), want his own reply", got, replayed)
+	}
+	if got, replayed := call("alice"); got != "reply for alice" || !replayed {
+		t.Fatalf("alice retrying: got %q (replayed %v), want her replayed reply", got, replayed)
+	}
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls)
+	}
+}
+
+// commitFailingStore claims keys in memory but never stores a reply.
+type commitFailingStore struct {
+	*MemoryStore
+}
+
+func (commitFailingStore) Commit(context.Context, string, *Record, time.Duration) error {
+	return errors.New("store unavailable")
+}
+
+func TestReplyIsReturnedWhenCommitFails(t *testing.T) {
+	var logs bytes.Buffer
+	calls := 0
+	handler := Server(
+		WithStore(commitFailingStore{NewMemoryStore()}),
+		WithLogger(log.NewStdLogger(&logs)),
+	)(func(context.Context, interface{}) (interface{}, error) {
+		calls++
+		return wrapperspb.String("created"), nil
+	})
+	call := func() {
+		tr := &testTransport{request: http.Header{}, reply: http.Header{}}
+		tr.request.Set(HeaderKey, "key-1")
+		reply, err := handler(transport.NewServerContext(context.Background(), tr), wrapperspb.String("request"))
+		if err != nil {
+			t.Fatalf("got error %v, want the handler's reply", err)
+		}
+		if reply.(*wrapperspb.StringValue).Value != "created" {
+			t.Fatalf("got reply %v", reply)
+		}
+	}
+
+	call()
+	if !strings.Contains(logs.String(), "store unavailable") {
+		t.Fatalf("the commit failure was not logged: %q", logs.String())
+	}
+	// The key was released, so a retry runs again instead of being held
+	// as in progress.
+	call()
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval bounds how often expired keys are evicted.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	record  *Record // nil while the claim is in progress
+	expires time.Time
+}
+
+// MemoryStore is a process-local Store. Replicas behind a load balancer do
+// not share it, so pin retries to one instance or use a shared Store.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore new an in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+// Begin implements Store.
+func (s *MemoryStore) Begin(_ context.Context, key string, ttl time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		if e.record == nil {
+			return nil, ErrInProgress
+		}
+		return e.record, nil
+	}
+	s.entries[key] = &memoryEntry{expires: now.Add(ttl)}
+	return nil, nil
+}
+
+// Commit implements Store.
+func (s *MemoryStore) Commit(_ context.Context, key string, rec *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = &memoryEntry{record: rec, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+// Abort implements Store.
+func (s *MemoryStore) Abort(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.record == nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// sweep evicts expired entries; callers hold s.mu.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
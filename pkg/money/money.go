@@ -0,0 +1,99 @@
+// Package money handles amounts in the units + nanos form of
+// api.common.Money (modelled on google.type.Money), and converts to and
+// from the legacy double prices that predate it.
+package money
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+// NanosPerUnit is the number of nanos in one whole unit.
+const NanosPerUnit = 1_000_000_000
+
+var (
+	currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+	decimal      = regexp.MustCompile(`^(-?)(\d+)(?:\.(\d{1,9}))?$`)
+)
+
+// FromFloat splits a legacy double amount into units and nanos, rounding to
+// the nearest nano.
+func FromFloat(f float64) (units int64, nanos int32) {
+	whole, frac := math.Modf(f)
+	units = int64(whole)
+	n := int64(math.Round(frac * NanosPerUnit))
+	if n == NanosPerUnit || n == -NanosPerUnit {
+		units += n / NanosPerUnit
+		n = 0
+	}
+	return units, int32(n)
+}
+
+// Float64 joins units and nanos into a double for the legacy fields. It is
+// not exact and must not be used for arithmetic.
+func Float64(units int64, nanos int32) float64 {
+	return float64(units) + float64(nanos)/NanosPerUnit
+}
+
+// Validate checks that currency is an upper-case ISO 4217 code and that
+// units and nanos form a valid amount: nanos within one unit and never of
+// the opposite sign.
+func Validate(currency string, units int64, nanos int32) error {
+	if !currencyCode.MatchString(currency) {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("invalid currency code %q", currency))
+	}
+	if nanos <= -NanosPerUnit || nanos >= NanosPerUnit {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "money nanos out of range")
+	}
+	if (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "money units and nanos must have the same sign")
+	}
+	return nil
+}
+
+// Parse reads a decimal amount such as "19.99" or "-3" exactly, without
+// going through a float. At most nine fractional digits are accepted.
+func Parse(s string) (units int64, nanos int32, err error) {
+	m := decimal.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if units, err = strconv.ParseInt(m[2], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid amount %q", s)
+	}
+	frac := m[3] + strings.Repeat("0", 9-len(m[3]))
+	n, _ := strconv.ParseInt(frac, 10, 32)
+	nanos = int32(n)
+	if m[1] == "-" {
+		units, nanos = -units, -nanos
+	}
+	return units, nanos, nil
+}
+
+// Format writes units and nanos as a decimal amount with at least two
+// fractional digits, the inverse of Parse.
+func Format(units int64, nanos int32) string {
+	sign := ""
+	if units < 0 || nanos < 0 {
+		sign = "-"
+	}
+	u, n := units, int64(nanos)
+	if u < 0 {
+		u = -u
+	}
+	if n < 0 {
+		n = -n
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%09d", n), "0")
+	if len(frac) < 2 {
+		frac += strings.Repeat("0", 2-len(frac))
+	}
+	return sign + strconv.FormatInt(u, 10) + "." + frac
+}
@@ -0,0 +1,90 @@
+package money
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseAndFormat(t *testing.T) {
+	cases := []struct {
+		in     string
+		units  int64
+		nanos  int32
+		format string
+	}{
+		{"19.99", 19, 990000000, "19.99"},
+		{"-3", -3, 0, "-3.00"},
+		{"-0.5", 0, -500000000, "-0.50"},
+		{" 0.000000001 ", 0, 1, "0.000000001"},
+		{"1.123456789", 1, 123456789, "1.123456789"},
+		{"9223372036854775807", math.MaxInt64, 0, "9223372036854775807.00"},
+	}
+	for _, c := range cases {
+		units, nanos, err := Parse(c.in)
+		if err != nil || units != c.units || nanos != c.nanos {
+			t.Errorf("Parse(%q) = %d, %d, %v; want %d, %d", c.in, units, nanos, err, c.units, c.nanos)
+			continue
+		}
+		if got := Format(units, nanos); got != c.format {
+			t.Errorf("Format(%d, %d) = %q, want %q", units, nanos, got, c.format)
+		}
+		if err := Validate("THB", units, nanos); err != nil {
+			t.Errorf("Validate(%q): %v", c.in, err)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "1.", ".5", "1.0000000001", "1,5", "+1", "9223372036854775808"} {
+		if _, _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	cases := []struct {
+		f     float64
+		units int64
+		nanos int32
+	}{
+		{19.99, 19, 990000000},
+		{-19.99, -19, -990000000},
+		{0.1 + 0.2, 0, 300000000},
+		{2.9999999999, 3, 0},
+		{-2.9999999999, -3, 0},
+	}
+	for _, c := range cases {
+		units, nanos := FromFloat(c.f)
+		if units != c.units || nanos != c.nanos {
+			t.Errorf("FromFloat(%v) = %d, %d; want %d, %d", c.f, units, nanos, c.units, c.nanos)
+		}
+		if got := Float64(units, nanos); math.Abs(got-c.f) > 1e-9 {
+			t.Errorf("Float64(%d, %d) = %v, want about %v", units, nanos, got, c.f)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		currency string
+		units    int64
+		nanos    int32
+		ok       bool
+	}{
+		{"positive", "THB", 1, 500000000, true},
+		{"negative", "USD", -1, -500000000, true},
+		{"nanos only", "EUR", 0, -1, true},
+		{"lower-case currency", "thb", 1, 0, false},
+		{"no currency", "", 1, 0, false},
+		{"nanos too large", "THB", 0, NanosPerUnit, false},
+		{"nanos too small", "THB", 0, -NanosPerUnit, false},
+		{"opposite signs", "THB", 1, -1, false},
+		{"opposite signs, negative units", "THB", -1, 1, false},
+	}
+	for _, c := range cases {
+		err := Validate(c.currency, c.units, c.nanos)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: Validate = %v, want ok=%v", c.name, err, c.ok)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNoSubscribers is returned by ChannelPublisher.Publish while nothing has
+// subscribed, so that the relay keeps the event instead of dropping it.
+var ErrNoSubscribers = errors.New("outbox: no channel subscribers")
+
+// ChannelPublisher delivers events to in-process subscribers. Publish waits
+// until every subscriber has received the event, so a subscriber must keep
+// receiving. Without subscribers Publish fails and events stay in the outbox.
+type ChannelPublisher struct {
+	mu   sync.RWMutex
+	subs []chan *Event
+}
+
+// NewChannelPublisher .
+func NewChannelPublisher() *ChannelPublisher {
+	return &ChannelPublisher{}
+}
+
+// Subscribe returns a channel that receives every event published from now
+// on. buffer sets how many events may wait unread before Publish blocks.
+func (p *ChannelPublisher) Subscribe(buffer int) <-chan *Event {
+	ch := make(chan *Event, buffer)
+	p.mu.Lock()
+	p.subs = append(p.subs, ch)
+	p.mu.Unlock()
+	return ch
+}
+
+// Publish implements Publisher.
+func (p *ChannelPublisher) Publish(ctx context.Context, e *Event) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.subs) == 0 {
+		return ErrNoSubscribers
+	}
+	for _, ch := range p.subs {
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
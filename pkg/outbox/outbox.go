@@ -0,0 +1,97 @@
+// Package outbox implements the transactional outbox. A service records
+// domain events with Append in the same database transaction as the change
+// they describe; a Relay later delivers them to a Publisher and removes them.
+//
+// Delivery is at least once: an event published just before a crash is
+// published again, so consumers must tolerate duplicates by Event.ID.
+//
+// Events live in the outbox_events table, which each service creates in its
+// own migrations:
+//
+//	CREATE TABLE outbox_events (
+//	    id VARCHAR(36) NOT NULL PRIMARY KEY,
+//	    source VARCHAR(32) NOT NULL,
+//	    event_type VARCHAR(64) NOT NULL,
+//	    aggregate_id VARCHAR(64) NOT NULL,
+//	    payload TEXT NOT NULL,
+//	    occurred_at DATETIME NOT NULL,
+//	    attempts INT NOT NULL DEFAULT 0,
+//	    last_error TEXT NOT NULL
+//	);
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types recorded by the product and inventory services.
+const (
+	TypeProductCreated = "ProductCreated"
+	TypeProductUpdated = "ProductUpdated"
+	TypeProductDeleted = "ProductDeleted"
+	TypeStockChanged   = "StockChanged"
+	TypeLowStock       = "LowStock"
+)
+
+// Event is a domain event. Its JSON form is the message body sent by the
+// broker adapters.
+type Event struct {
+	ID          string          `json:"id"`           // unique and stable across redeliveries
+	Source      string          `json:"source"`       // emitting service, e.g. "inventory"
+	Type        string          `json:"type"`         // one of the Type constants
+	AggregateID string          `json:"aggregate_id"` // the product the event is about
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// Publisher delivers events to consumers. Publish returns nil only once the
+// event has been handed off; on error the event stays in the outbox and is
+// retried.
+type Publisher interface {
+	Publish(ctx context.Context, e *Event) error
+}
+
+// Execer is satisfied by both *sql.DB and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// NewEvent builds an event with a new time-ordered ID and payload encoded as
+// JSON.
+func NewEvent(source, eventType, aggregateID string, payload any) (*Event, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("new event: %w", err)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", eventType, err)
+	}
+	return &Event{
+		ID:          id.String(),
+		Source:      source,
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     body,
+		OccurredAt:  time.Now().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Append records e in the outbox through db, which should be the
+// transaction making the change e describes.
+func Append(ctx context.Context, db Execer, e *Event) error {
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO outbox_events (id, source, event_type, aggregate_id, payload, occurred_at, attempts, last_error)
+		VALUES (?, ?, ?, ?, ?, ?, 0, '')`,
+		e.ID, e.Source, e.Type, e.AggregateID, string(e.Payload), e.OccurredAt,
+	); err != nil {
+		return fmt.Errorf("append %s event: %w", e.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package outboxkafka publishes outbox events to Kafka.
+package outboxkafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+)
+
+// Writer is the part of *kafka.Writer used by Publisher.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Publisher is an outbox.Publisher that writes each event, as JSON, keyed
+// by its aggregate ID so that a product's events share a partition and keep
+// their order. The event ID, type and source are also sent as headers.
+type Publisher struct {
+	w Writer
+}
+
+// NewPublisher .
+func NewPublisher(w Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+// NewWriter returns a synchronous writer to topic that waits for every
+// in-sync replica to acknowledge a message, as at-least-once delivery needs.
+func NewWriter(brokers []string, topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+}
+
+// Publish implements outbox.Publisher.
+func (p *Publisher) Publish(ctx context.Context, e *outbox.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := p.w.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(e.AggregateID),
+		Value: body,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(e.ID)},
+			{Key: "event-type", Value: []byte(e.Type)},
+			{Key: "event-source", Value: []byte(e.Source)},
+		},
+	}); err != nil {
+		return fmt.Errorf("write to kafka: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package outboxkafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+)
+
+type fakeWriter struct {
+	msgs []kafka.Message
+	err  error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.msgs = append(w.msgs, msgs...)
+	return w.err
+}
+
+func TestPublish(t *testing.T) {
+	w := &fakeWriter{}
+	e := &outbox.Event{ID: "e1", Source: "product", Type: outbox.TypeProductCreated, AggregateID: "42", Payload: json.RawMessage(`{}`)}
+
+	if err := NewPublisher(w).Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(w.msgs) != 1 {
+		t.Fatalf("wrote %d messages", len(w.msgs))
+	}
+	msg := w.msgs[0]
+	if string(msg.Key) != "42" {
+		t.Fatalf("keyed by %q, want the aggregate id", msg.Key)
+	}
+	headers := map[string]string{}
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	if headers["event-id"] != "e1" || headers["event-type"] != outbox.TypeProductCreated || headers["event-source"] != "product" {
+		t.Fatalf("headers %v", headers)
+	}
+	var got outbox.Event
+	if err := json.Unmarshal(msg.Value, &got); err != nil || got.ID != "e1" {
+		t.Fatalf("value %s: %v", msg.Value, err)
+	}
+
+	w.err = errors.New("not enough replicas")
+	if err := NewPublisher(w).Publish(context.Background(), e); err == nil {
+		t.Fatal("Publish hid a write error")
+	}
+}
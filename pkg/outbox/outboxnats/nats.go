@@ -0,0 +1,64 @@
+// Package outboxnats publishes outbox events to NATS JetStream.
+package outboxnats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+)
+
+// JetStream is the part of jetstream.JetStream used by Publisher.
+type JetStream interface {
+	Publish(ctx context.Context, subject string, payload []byte, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// Publisher is an outbox.Publisher that sends each event, as JSON, to the
+// subject "<prefix>.<source>.<type>", e.g. "events.inventory.StockChanged".
+// A stream must capture those subjects: JetStream acknowledges a message
+// only once the stream has stored it. The event ID is sent as the message
+// ID, so the stream drops redeliveries within its duplicate window.
+type Publisher struct {
+	js     JetStream
+	prefix string
+}
+
+// NewPublisher .
+func NewPublisher(js JetStream, prefix string) *Publisher {
+	return &Publisher{js: js, prefix: prefix}
+}
+
+// Connect connects to the NATS server at url and returns a Publisher using
+// its JetStream, and a function that closes the connection.
+func Connect(url, prefix string) (*Publisher, func(), error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to nats %s: %w", url, err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, nil, fmt.Errorf("open jetstream: %w", err)
+	}
+	return NewPublisher(js, prefix), nc.Close, nil
+}
+
+// Publish implements outbox.Publisher.
+func (p *Publisher) Publish(ctx context.Context, e *outbox.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	subject := e.Source + "." + e.Type
+	if p.prefix != "" {
+		subject = p.prefix + "." + subject
+	}
+	if _, err := p.js.Publish(ctx, subject, body, jetstream.WithMsgID(e.ID)); err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package outboxnats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+)
+
+type fakeJetStream struct {
+	subject string
+	payload []byte
+	opts    int
+	err     error
+}
+
+func (js *fakeJetStream) Publish(_ context.Context, subject string, payload []byte, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	js.subject, js.payload, js.opts = subject, payload, len(opts)
+	return &jetstream.PubAck{}, js.err
+}
+
+func TestPublish(t *testing.T) {
+	js := &fakeJetStream{}
+	e := &outbox.Event{ID: "e1", Source: "inventory", Type: outbox.TypeStockChanged, AggregateID: "p1", Payload: json.RawMessage(`{}`)}
+
+	if err := NewPublisher(js, "events").Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if js.subject != "events.inventory.StockChanged" {
+		t.Fatalf("published to %q", js.subject)
+	}
+	var got outbox.Event
+	if err := json.Unmarshal(js.payload, &got); err != nil || got.ID != "e1" || got.AggregateID != "p1" {
+		t.Fatalf("payload %s: %v", js.payload, err)
+	}
+	if js.opts != 1 {
+		t.Fatalf("published with %d options, want the message ID", js.opts)
+	}
+
+	if err := NewPublisher(js, "").Publish(context.Background(), e); err != nil || js.subject != "inventory.StockChanged" {
+		t.Fatalf("without a prefix published to %q: %v", js.subject, err)
+	}
+
+	js.err = errors.New("no responders")
+	if err := NewPublisher(js, "events").Publish(context.Background(), e); err == nil {
+		t.Fatal("Publish hid a JetStream error")
+	}
+}
@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Relay defaults, used when NewRelay is given zero values.
+const (
+	DefaultInterval  = time.Second
+	DefaultBatchSize = 100
+)
+
+// Relay polls a Store and publishes its events in order. An event that fails
+// to publish holds back the events behind it until it succeeds, so consumers
+// never see a product's events out of order. Relay is a transport.Server so
+// the app starts and stops it with its other servers.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+	log       *log.Helper
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRelay new an outbox relay.
+func NewRelay(store Store, publisher Publisher, interval time.Duration, batchSize int, logger log.Logger) *Relay {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// Start runs the relay loop until Stop is called.
+func (r *Relay) Start(ctx context.Context) error {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+				r.log.Errorf("outbox relay: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the relay loop and waits for an in-flight batch to finish.
+func (r *Relay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Flush publishes pending events until the outbox is empty or an event
+// fails, and reports how many were delivered.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		events, err := r.store.Pending(ctx, r.batchSize)
+		if err != nil {
+			return delivered, err
+		}
+		for _, e := range events {
+			if err := r.publisher.Publish(ctx, e); err != nil {
+				if ferr := r.store.Failed(ctx, e.ID, err); ferr != nil {
+					r.log.Error(ferr)
+				}
+				return delivered, fmt.Errorf("publish %s event %s: %w", e.Type, e.ID, err)
+			}
+			// A crash before this point publishes e again on restart.
+			if err := r.store.Delivered(ctx, e.ID); err != nil {
+				return delivered, err
+			}
+			delivered++
+		}
+		if len(events) < r.batchSize {
+			return delivered, nil
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// memStore is a Store over a slice.
+type memStore struct {
+	events   []*Event
+	failures map[string]int
+}
+
+func (s *memStore) Pending(_ context.Context, limit int) ([]*Event, error) {
+	return s.events[:min(limit, len(s.events))], nil
+}
+
+func (s *memStore) Delivered(_ context.Context, id string) error {
+	for i, e := range s.events {
+		if e.ID == id {
+			s.events = append(s.events[:i:i], s.events[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("event %s not pending", id)
+}
+
+func (s *memStore) Failed(_ context.Context, id string, _ error) error {
+	s.failures[id]++
+	return nil
+}
+
+// flakyPublisher records what it publishes and fails on the IDs in failOn.
+type flakyPublisher struct {
+	published []string
+	failOn    map[string]bool
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, e *Event) error {
+	if p.failOn[e.ID] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, e.ID)
+	return nil
+}
+
+func TestRelayFlush(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{failures: map[string]int{}}
+	for i := 0; i < 5; i++ {
+		store.events = append(store.events, &Event{ID: fmt.Sprintf("e%d", i), Type: TypeStockChanged})
+	}
+	publisher := &flakyPublisher{failOn: map[string]bool{"e2": true}}
+	// A batch smaller than the outbox makes Flush read several batches.
+	relay := NewRelay(store, publisher, 0, 2, log.DefaultLogger)
+
+	n, err := relay.Flush(ctx)
+	if err == nil {
+		t.Fatal("Flush succeeded past a failing event")
+	}
+	if n != 2 || fmt.Sprint(publisher.published) != "[e0 e1]" {
+		t.Fatalf("delivered %d events %v, want e0 e1 only", n, publisher.published)
+	}
+	if store.failures["e2"] != 1 {
+		t.Fatalf("failed event recorded %d times, want 1", store.failures["e2"])
+	}
+	if len(store.events) != 3 || store.events[0].ID != "e2" {
+		t.Fatalf("outbox holds %d events starting at %s, want e2 e3 e4", len(store.events), store.events[0].ID)
+	}
+
+	// Once the broker recovers the held-back events follow in order.
+	publisher.failOn = nil
+	if n, err = relay.Flush(ctx); err != nil || n != 3 {
+		t.Fatalf("second Flush delivered %d events: %v", n, err)
+	}
+	if fmt.Sprint(publisher.published) != "[e0 e1 e2 e3 e4]" || len(store.events) != 0 {
+		t.Fatalf("published %v, %d left", publisher.published, len(store.events))
+	}
+}
+
+func TestRelayKeepsEventsWithoutChannelSubscribers(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{events: []*Event{{ID: "e0"}}, failures: map[string]int{}}
+	channel := NewChannelPublisher()
+	relay := NewRelay(store, channel, 0, 0, log.DefaultLogger)
+
+	if _, err := relay.Flush(ctx); !errors.Is(err, ErrNoSubscribers) {
+		t.Fatalf("Flush without subscribers: got %v, want ErrNoSubscribers", err)
+	}
+	if len(store.events) != 1 {
+		t.Fatal("event was dropped while nothing subscribed")
+	}
+
+	events := channel.Subscribe(1)
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("Flush with a subscriber delivered %d: %v", n, err)
+	}
+	if e := <-events; e.ID != "e0" {
+		t.Fatalf("subscriber received %s", e.ID)
+	}
+}
@@ -0,0 +1,73 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Store reads and settles the events waiting in an outbox.
+type Store interface {
+	// Pending returns up to limit undelivered events, oldest first.
+	Pending(ctx context.Context, limit int) ([]*Event, error)
+	// Delivered removes an event that has been published.
+	Delivered(ctx context.Context, id string) error
+	// Failed records a failed attempt to publish an event.
+	Failed(ctx context.Context, id string, cause error) error
+}
+
+// SQLStore is the Store over the outbox_events table.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore .
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Pending implements Store. Event IDs are time-ordered, so ordering by ID
+// follows the order the events were recorded in.
+func (s *SQLStore) Pending(ctx context.Context, limit int) ([]*Event, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, source, event_type, aggregate_id, payload, occurred_at FROM outbox_events ORDER BY id LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("read outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*Event
+	for rows.Next() {
+		var (
+			e       Event
+			payload string
+		)
+		if err := rows.Scan(&e.ID, &e.Source, &e.Type, &e.AggregateID, &payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("read outbox: %w", err)
+		}
+		e.Payload = []byte(payload)
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read outbox: %w", err)
+	}
+	return events, nil
+}
+
+// Delivered implements Store.
+func (s *SQLStore) Delivered(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("settle event %s: %w", id, err)
+	}
+	return nil
+}
+
+// Failed implements Store.
+func (s *SQLStore) Failed(ctx context.Context, id string, cause error) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE outbox_events SET attempts = attempts + 1, last_error = ? WHERE id = ?`, cause.Error(), id,
+	); err != nil {
+		return fmt.Errorf("record failure of event %s: %w", id, err)
+	}
+	return nil
+}
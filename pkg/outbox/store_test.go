@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestDB returns a SQLite database holding the outbox_events table.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "outbox.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE outbox_events (
+		id VARCHAR(36) NOT NULL PRIMARY KEY,
+		source VARCHAR(32) NOT NULL,
+		event_type VARCHAR(64) NOT NULL,
+		aggregate_id VARCHAR(64) NOT NULL,
+		payload TEXT NOT NULL,
+		occurred_at DATETIME NOT NULL,
+		attempts INT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestSQLStore(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	store := NewSQLStore(db)
+
+	var ids []string
+	for _, aggregate := range []string{"p1", "p2", "p1"} {
+		e, err := NewEvent("inventory", TypeStockChanged, aggregate, map[string]string{"product_id": aggregate})
+		if err != nil {
+			t.Fatalf("NewEvent: %v", err)
+		}
+		if err := Append(ctx, db, e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		ids = append(ids, e.ID)
+	}
+
+	pending, err := store.Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("Pending returned %d events, want 3", len(pending))
+	}
+	for i, e := range pending {
+		if e.ID != ids[i] {
+			t.Fatalf("event %d is %s, want %s in recording order", i, e.ID, ids[i])
+		}
+	}
+	if string(pending[1].Payload) != `{"product_id":"p2"}` || pending[1].Source != "inventory" {
+		t.Fatalf("event read back as %+v", pending[1])
+	}
+	if limited, _ := store.Pending(ctx, 2); len(limited) != 2 {
+		t.Fatalf("Pending(2) returned %d events", len(limited))
+	}
+
+	if err := store.Failed(ctx, ids[0], errors.New("broker down")); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var (
+		attempts  int
+		lastError string
+	)
+	if err := db.QueryRow(`SELECT attempts, last_error FROM outbox_events WHERE id = ?`, ids[0]).Scan(&attempts, &lastError); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 1 || lastError != "broker down" {
+		t.Fatalf("failed event has attempts=%d, last_error=%q", attempts, lastError)
+	}
+
+	if err := store.Delivered(ctx, ids[0]); err != nil {
+		t.Fatalf("Delivered: %v", err)
+	}
+	if pending, _ = store.Pending(ctx, 10); len(pending) != 2 || pending[0].ID != ids[1] {
+		t.Fatalf("after Delivered the outbox holds %d events", len(pending))
+	}
+}
@@ -0,0 +1,162 @@
+// Package sqldb opens the MySQL or embedded SQLite database of a service,
+// applies its versioned migrations and binds transactions to a context.
+//
+// Repositories run their queries on Conn(ctx), which returns the
+// transaction started by InTx when there is one, so a use case can group
+// several repository calls into one transaction without passing it around.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Supported values for conf.Data.Database.Driver.
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+// Dialect rewrites a statement written for MySQL, the production database,
+// into the SQL understood by another driver. It is applied to migrations.
+type Dialect func(stmt string) string
+
+// sqliteTypes rewrites the MySQL-only column syntax used by the migrations.
+// SQLite only auto-assigns ids to a column declared exactly INTEGER PRIMARY KEY.
+var sqliteTypes = strings.NewReplacer(
+	"BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT", "INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT",
+)
+
+// sqliteDropIndex matches MySQL's "DROP INDEX <name> ON <table>"; index
+// names are global in SQLite, which takes no table.
+var sqliteDropIndex = regexp.MustCompile(`(?is)^(DROP INDEX\s+\w+)\s+ON\s+\w+$`)
+
+func sqliteDialect(stmt string) string {
+	stmt = sqliteTypes.Replace(stmt)
+	return sqliteDropIndex.ReplaceAllString(stmt, "$1")
+}
+
+// dialects holds the rewrite of each driver; MySQL needs none.
+var dialects = map[string]Dialect{
+	DriverSQLite: sqliteDialect,
+}
+
+// Querier is the subset of *sql.DB and *sql.Tx used by the repositories.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// DB is a connection pool together with the driver it was opened with.
+type DB struct {
+	db      *sql.DB
+	driver  string
+	dialect Dialect
+}
+
+// Open connects to the database and checks that it is reachable. "sqlite3"
+// is accepted as an alias of DriverSQLite.
+func Open(ctx context.Context, driver, source string) (*DB, error) {
+	switch driver {
+	case DriverMySQL, DriverSQLite:
+	case "sqlite3":
+		driver = DriverSQLite
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %q", driver)
+	}
+
+	db, err := sql.Open(driver, source)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if driver == DriverSQLite {
+		// SQLite allows a single writer; serialising connections also keeps
+		// ":memory:" databases shared across the pool.
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+
+	dialect := dialects[driver]
+	if dialect == nil {
+		dialect = func(stmt string) string { return stmt }
+	}
+	return &DB{db: db, driver: driver, dialect: dialect}, nil
+}
+
+// SQL returns the underlying connection pool.
+func (d *DB) SQL() *sql.DB {
+	return d.db
+}
+
+// Driver returns DriverMySQL or DriverSQLite.
+func (d *DB) Driver() string {
+	return d.driver
+}
+
+// Close closes the connection pool.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+type contextTxKey struct{}
+
+// Conn returns the transaction bound to ctx by InTx, or the connection pool.
+func (d *DB) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(contextTxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return d.db
+}
+
+// InTx runs fn inside a database transaction. Nested calls join the
+// transaction already bound to ctx.
+func (d *DB) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(contextTxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, contextTxKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ForUpdate returns the row-locking clause for SELECTs run inside InTx.
+// SQLite serialises transactions on its single connection and needs none.
+func (d *DB) ForUpdate() string {
+	if d.driver == DriverMySQL {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
+// IsDuplicateKey reports whether err is a unique constraint violation.
+func IsDuplicateKey(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == 1062 // ER_DUP_ENTRY
+	}
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	return false
+}
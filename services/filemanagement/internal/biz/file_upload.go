@@ -6,8 +6,15 @@ import (
 	"encoding/hex"
 	"fmt"
 	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
 )
 
+// ErrFileNotFound is returned when no file exists for the given file ID.
+var ErrFileNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "file not found")
+
 // FileUploadUseCase handles file upload business logic
 type FileUploadUseCase struct {
 	storage FileStorage
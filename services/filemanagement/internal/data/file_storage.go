@@ -54,7 +54,7 @@ func (s *LocalFileStorage) ConfirmUpload(ctx context.Context, fileID string) err
 	
 	// Check if directory exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return fmt.Errorf("file not found: %s", fileID)
+		return biz.ErrFileNotFound
 	}
 	
 	return nil
@@ -78,7 +78,7 @@ func (s *LocalFileStorage) GetFileInfo(ctx context.Context, fileID string) (*biz
 	info, err := os.Stat(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("file not found: %s", fileID)
+			return nil, biz.ErrFileNotFound
 		}
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
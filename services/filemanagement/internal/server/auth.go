@@ -0,0 +1,17 @@
+package server
+
+import (
+	v1 "github.com/reverny/kratos-mono/gen/go/api/filemanagement/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/filemanagement/internal/conf"
+)
+
+// policy holds the roles required by the RPCs annotated with
+// (api.auth.required_role) in filemanagement.proto.
+var policy = auth.MustPolicy(v1.File_filemanagement_v1_filemanagement_proto)
+
+// NewVerifier returns the access token verifier shared by the gRPC and HTTP
+// servers.
+func NewVerifier(c *conf.Server) (*auth.Verifier, error) {
+	return auth.NewVerifierFromConfig(c.GetAuth())
+}
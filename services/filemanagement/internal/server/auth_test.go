@@ -0,0 +1,18 @@
+package server
+
+import (
+	"testing"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/filemanagement/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/auth/authztest"
+)
+
+func TestPolicy(t *testing.T) {
+	authztest.Run(t, policy, []authztest.Case{
+		{Operation: v1.Filemanagement_RequestUploadUrl_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Filemanagement_ConfirmUpload_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Filemanagement_GetFileInfo_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Filemanagement_DeleteFile_FullMethodName, Role: auth.RoleUser, Allowed: true},
+	})
+}
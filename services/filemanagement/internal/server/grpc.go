@@ -2,6 +2,7 @@ package server
 
 import (
 	v1 "github.com/reverny/kratos-mono/gen/go/api/filemanagement/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
 	"github.com/reverny/kratos-mono/services/filemanagement/internal/conf"
 	"github.com/reverny/kratos-mono/services/filemanagement/internal/service"
 
@@ -10,10 +11,11 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
-func NewGRPCServer(c *conf.Server, filemanagementSvc *service.FilemanagementService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, verifier *auth.Verifier, filemanagementSvc *service.FilemanagementService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			auth.Middleware(verifier, policy),
 		),
 	}
 	if c.Grpc.Network != "" {
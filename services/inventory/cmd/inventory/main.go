@@ -11,7 +11,9 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
 
+	"github.com/reverny/kratos-mono/pkg/outbox"
 	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+	"github.com/reverny/kratos-mono/services/inventory/internal/server"
 )
 
 // go build -ldflags "-X main.Version=x.y.z"
@@ -30,7 +32,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, ps *server.PurgeServer, relay *outbox.Relay) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -40,6 +42,8 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			ps,
+			relay,
 		),
 	)
 }
@@ -69,7 +73,7 @@ func main() {
 		panic(err)
 	}
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Inventory, logger)
 	if err != nil {
 		panic(err)
 	}
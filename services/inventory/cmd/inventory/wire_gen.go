@@ -9,6 +9,7 @@ package main
 import (
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/reverny/kratos-mono/pkg/outbox"
 	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
 	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
 	"github.com/reverny/kratos-mono/services/inventory/internal/data"
@@ -19,18 +20,43 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, inventory *conf.Inventory, logger log.Logger) (*kratos.App, func(), error) {
+	verifier, err := server.NewVerifier(confServer)
+	if err != nil {
+		return nil, nil, err
+	}
+	store := server.NewIdempotencyStore()
 	dataData, cleanup, err := data.NewData(confData, logger)
 	if err != nil {
 		return nil, nil, err
 	}
 	inventoryRepo := data.NewInventoryRepo(dataData, logger)
-	inventoryUsecase := biz.NewInventoryUsecase(inventoryRepo, logger)
-	inventoryService := service.NewInventoryService(inventoryUsecase)
-	grpcServer := server.NewGRPCServer(confServer, inventoryService, logger)
-	httpServer := server.NewHTTPServer(confServer, inventoryService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	catalog, cleanup2, err := data.NewCatalog(confData, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	outboxEventPublisher := data.NewOutboxEventPublisher(dataData, logger)
+	inventoryUsecase := biz.NewInventoryUsecase(inventoryRepo, catalog, outboxEventPublisher, logger)
+	reservationRepo := data.NewReservationRepo(dataData, logger)
+	reservationUsecase := biz.NewReservationUsecase(reservationRepo, inventory, logger)
+	locationRepo := data.NewLocationRepo(dataData, logger)
+	locationUsecase := biz.NewLocationUsecase(locationRepo, logger)
+	inventoryService := service.NewInventoryService(inventoryUsecase, reservationUsecase, locationUsecase)
+	grpcServer := server.NewGRPCServer(confServer, verifier, inventory, store, inventoryService, logger)
+	httpServer := server.NewHTTPServer(confServer, verifier, inventory, store, inventoryService, logger)
+	purgeServer := server.NewPurgeServer(inventory, inventoryUsecase, logger)
+	channelPublisher := outbox.NewChannelPublisher()
+	relay, cleanup3, err := data.NewOutboxRelay(confData, dataData, channelPublisher, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	app := newApp(logger, grpcServer, httpServer, purgeServer, relay)
 	return app, func() {
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
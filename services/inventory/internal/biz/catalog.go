@@ -0,0 +1,82 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/reverny/kratos-mono/pkg/fieldmask"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// Catalog is the product service, which owns product identity. Inventory
+// products refer to their catalog entry by its id and keep the stock,
+// pricing and variant data the catalog does not hold.
+type Catalog interface {
+	// ResolveProduct returns the id of the catalog product with sku,
+	// creating it with name when the catalog has none. It returns 0 when no
+	// catalog is configured.
+	ResolveProduct(ctx context.Context, sku, name string) (int64, error)
+	// RenameProduct sets the name of the catalog product id.
+	RenameProduct(ctx context.Context, id int64, name string) error
+}
+
+// createProduct links req to its catalog entry and stores it. The catalog
+// lookup is by SKU, so retrying after a failed insert reuses the same entry.
+func (uc *InventoryUsecase) createProduct(ctx context.Context, req *dto.CreateProductDTO) (*dto.ProductDTO, error) {
+	catalogID, err := uc.catalog.ResolveProduct(ctx, req.SKU, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	req.CatalogID = catalogID
+	return uc.repo.CreateProduct(ctx, req)
+}
+
+// updateProduct stores req and keeps the catalog entry in step: a product
+// stored before it was linked is linked now, and a masked name is copied to
+// the catalog once the local write has committed. The local product is the
+// record of the write, so a failed rename is logged rather than returned;
+// the next update that carries the name applies it again.
+func (uc *InventoryUsecase) updateProduct(ctx context.Context, req *dto.UpdateProductDTO) (*dto.ProductDTO, error) {
+	current, err := uc.repo.GetProduct(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Fail before touching the catalog when the write is bound to lose.
+	if req.ExpectedVersion > 0 && req.ExpectedVersion != current.Version {
+		return nil, ErrVersionConflict
+	}
+
+	renamed := fieldmask.Has(req.UpdateMask, dto.ProductFieldName)
+	if current.CatalogID == 0 {
+		name := current.Name
+		if renamed {
+			name = req.Name
+		}
+		// The lookup is by SKU, so an entry created here is found again if
+		// the write below fails.
+		catalogID, err := uc.catalog.ResolveProduct(ctx, current.SKU, name)
+		if err != nil {
+			return nil, err
+		}
+		if catalogID != 0 {
+			req.CatalogID = catalogID
+			req.UpdateMask = append(req.UpdateMask, dto.ProductFieldCatalogID)
+		}
+	}
+
+	updated, err := uc.repo.UpdateProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if renamed && updated.CatalogID != 0 {
+		if err := uc.catalog.RenameProduct(ctx, updated.CatalogID, updated.Name); err != nil {
+			uc.log.WithContext(ctx).Warnf("product %s updated but the catalog name was not: %v", updated.ID, err)
+		}
+	}
+	return updated, nil
+}
+
+// GetProductByCatalogID gets the Product linked to a catalog product.
+func (uc *InventoryUsecase) GetProductByCatalogID(ctx context.Context, catalogID int64) (*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("GetProductByCatalogID: %v", catalogID)
+	return uc.repo.GetProductByCatalogID(ctx, catalogID)
+}
@@ -0,0 +1,166 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/pkg/fieldmask"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// fakeCatalog hands out catalog ids by SKU and records every call, in
+// order, in calls.
+type fakeCatalog struct {
+	ids       map[string]int64
+	renameErr error
+	calls     *[]string
+}
+
+func (c *fakeCatalog) ResolveProduct(_ context.Context, sku, name string) (int64, error) {
+	*c.calls = append(*c.calls, "resolve "+sku+" "+name)
+	if _, ok := c.ids[sku]; !ok {
+		c.ids[sku] = int64(len(c.ids) + 1)
+	}
+	return c.ids[sku], nil
+}
+
+func (c *fakeCatalog) RenameProduct(_ context.Context, id int64, name string) error {
+	*c.calls = append(*c.calls, fmt.Sprintf("rename %d %s", id, name))
+	return c.renameErr
+}
+
+// catalogRepo stores a single product and records its writes in calls.
+type catalogRepo struct {
+	InventoryRepo
+	product   dto.ProductDTO
+	updateErr error
+	calls     *[]string
+}
+
+func (r *catalogRepo) CreateProduct(_ context.Context, req *dto.CreateProductDTO) (*dto.ProductDTO, error) {
+	*r.calls = append(*r.calls, "create")
+	r.product = dto.ProductDTO{ID: "p1", Name: req.Name, SKU: req.SKU, CatalogID: req.CatalogID, Version: 1}
+	p := r.product
+	return &p, nil
+}
+
+func (r *catalogRepo) GetProduct(context.Context, string) (*dto.ProductDTO, error) {
+	p := r.product
+	return &p, nil
+}
+
+func (r *catalogRepo) UpdateProduct(_ context.Context, req *dto.UpdateProductDTO) (*dto.ProductDTO, error) {
+	*r.calls = append(*r.calls, "update")
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	if fieldmask.Has(req.UpdateMask, dto.ProductFieldName) {
+		r.product.Name = req.Name
+	}
+	if fieldmask.Has(req.UpdateMask, dto.ProductFieldCatalogID) {
+		r.product.CatalogID = req.CatalogID
+	}
+	r.product.Version++
+	p := r.product
+	return &p, nil
+}
+
+func TestCatalogLink(t *testing.T) {
+	ctx := context.Background()
+	setup := func(product dto.ProductDTO) (*InventoryUsecase, *fakeCatalog, *catalogRepo, *[]string) {
+		calls := &[]string{}
+		catalog := &fakeCatalog{ids: map[string]int64{"MUG-1": 7}, calls: calls}
+		repo := &catalogRepo{product: product, calls: calls}
+		return NewInventoryUsecase(repo, catalog, nil, log.DefaultLogger), catalog, repo, calls
+	}
+	rename := func(name string, version int64) *dto.UpdateProductDTO {
+		return &dto.UpdateProductDTO{ID: "p1", Name: name, ExpectedVersion: version, UpdateMask: []string{dto.ProductFieldName}}
+	}
+	linked := dto.ProductDTO{ID: "p1", Name: "Mug", SKU: "MUG-1", CatalogID: 7, Version: 1}
+
+	t.Run("create resolves the catalog entry first", func(t *testing.T) {
+		uc, _, _, calls := setup(dto.ProductDTO{})
+		created, err := uc.CreateProduct(ctx, &dto.CreateProductDTO{Name: "Cup", SKU: "CUP-1"})
+		if err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+		if created.CatalogID != 2 || !slices.Equal(*calls, []string{"resolve CUP-1 Cup", "create"}) {
+			t.Fatalf("catalog id %d after %v", created.CatalogID, *calls)
+		}
+	})
+
+	t.Run("rename follows the local write", func(t *testing.T) {
+		uc, _, _, calls := setup(linked)
+		updated, err := uc.UpdateProduct(ctx, rename("Large mug", 1))
+		if err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+		if updated.Name != "Large mug" || !slices.Equal(*calls, []string{"update", "rename 7 Large mug"}) {
+			t.Fatalf("updated %+v after %v", updated, *calls)
+		}
+	})
+
+	t.Run("a failed rename keeps the local write", func(t *testing.T) {
+		uc, catalog, repo, _ := setup(linked)
+		catalog.renameErr = errors.New("catalog unavailable")
+		if _, err := uc.UpdateProduct(ctx, rename("Large mug", 1)); err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+		if repo.product.Name != "Large mug" || repo.product.Version != 2 {
+			t.Fatalf("stored %+v", repo.product)
+		}
+	})
+
+	t.Run("a failed local write leaves the catalog alone", func(t *testing.T) {
+		uc, _, repo, calls := setup(linked)
+		repo.updateErr = ErrVersionConflict
+		if _, err := uc.UpdateProduct(ctx, rename("Large mug", 0)); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("got %v, want ErrVersionConflict", err)
+		}
+		if !slices.Equal(*calls, []string{"update"}) {
+			t.Fatalf("calls %v", *calls)
+		}
+	})
+
+	t.Run("a stale version never reaches the catalog", func(t *testing.T) {
+		uc, _, _, calls := setup(linked)
+		if _, err := uc.UpdateProduct(ctx, rename("Large mug", 5)); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("got %v, want ErrVersionConflict", err)
+		}
+		if len(*calls) != 0 {
+			t.Fatalf("calls %v", *calls)
+		}
+	})
+
+	t.Run("no rename without the name in the mask", func(t *testing.T) {
+		uc, _, _, calls := setup(linked)
+		threshold := int32(3)
+		if _, err := uc.UpdateProduct(ctx, &dto.UpdateProductDTO{
+			ID: "p1", ReorderThreshold: &threshold, UpdateMask: []string{dto.ProductFieldReorderThreshold},
+		}); err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+		if !slices.Equal(*calls, []string{"update"}) {
+			t.Fatalf("calls %v", *calls)
+		}
+	})
+
+	t.Run("an unlinked product is linked on update", func(t *testing.T) {
+		unlinked := linked
+		unlinked.CatalogID = 0
+		uc, _, _, calls := setup(unlinked)
+		updated, err := uc.UpdateProduct(ctx, rename("Large mug", 1))
+		if err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+		want := []string{"resolve MUG-1 Large mug", "update", "rename 7 Large mug"}
+		if updated.CatalogID != 7 || !slices.Equal(*calls, want) {
+			t.Fatalf("catalog id %d after %v", updated.CatalogID, *calls)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// EventPublisher delivers inventory domain events to downstream consumers.
+// Events are published after the change that caused them has committed.
+type EventPublisher interface {
+	PublishLowStock(context.Context, *dto.LowStockEventDTO) error
+}
@@ -0,0 +1,97 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// stockRepo applies stock changes to a single in-memory product.
+type stockRepo struct {
+	InventoryRepo
+	product dto.ProductDTO
+}
+
+func (r *stockRepo) UpdateStock(_ context.Context, req *dto.UpdateStockDTO) (*dto.ProductDTO, error) {
+	if req.Operation == "subtract" {
+		r.product.Stock -= req.Quantity
+	} else {
+		r.product.Stock += req.Quantity
+	}
+	p := r.product
+	return &p, nil
+}
+
+func (r *stockRepo) BatchUpdateStock(ctx context.Context, items []*dto.UpdateStockDTO) ([]*dto.ProductDTO, error) {
+	products := make([]*dto.ProductDTO, len(items))
+	for i, item := range items {
+		products[i], _ = r.UpdateStock(ctx, item)
+	}
+	return products, nil
+}
+
+type recordingPublisher struct {
+	events []*dto.LowStockEventDTO
+}
+
+func (p *recordingPublisher) PublishLowStock(_ context.Context, e *dto.LowStockEventDTO) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestLowStockEventFiresWhenCrossingThreshold(t *testing.T) {
+	ctx := context.Background()
+	repo := &stockRepo{product: dto.ProductDTO{ID: "p1", SKU: "MUG-1", Stock: 8, ReorderThreshold: 5}}
+	publisher := &recordingPublisher{}
+	uc := NewInventoryUsecase(repo, nil, publisher, log.DefaultLogger)
+
+	steps := []struct {
+		operation string
+		quantity  int32
+		fires     bool
+	}{
+		{"subtract", 2, false}, // 8 -> 6, still above
+		{"subtract", 1, true},  // 6 -> 5, reaches the threshold
+		{"subtract", 1, false}, // 5 -> 4, already low
+		{"add", 10, false},     // 4 -> 14
+		{"subtract", 10, true}, // 14 -> 4, crosses in one step
+	}
+	for i, s := range steps {
+		before := len(publisher.events)
+		if _, err := uc.UpdateStock(ctx, &dto.UpdateStockDTO{ID: "p1", Operation: s.operation, Quantity: s.quantity}); err != nil {
+			t.Fatalf("step %d: UpdateStock: %v", i, err)
+		}
+		if fired := len(publisher.events) > before; fired != s.fires {
+			t.Fatalf("step %d: %s %d to stock %d: fired=%v, want %v", i, s.operation, s.quantity, repo.product.Stock, fired, s.fires)
+		}
+	}
+	if e := publisher.events[len(publisher.events)-1]; e.ProductID != "p1" || e.Stock != 4 || e.ReorderThreshold != 5 {
+		t.Fatalf("last event %+v", e)
+	}
+
+	// Each batch item is checked against the stock it left behind.
+	repo.product.Stock = 7
+	publisher.events = nil
+	if _, err := uc.BatchUpdateStock(ctx, []*dto.UpdateStockDTO{
+		{ID: "p1", Operation: "subtract", Quantity: 1}, // 7 -> 6
+		{ID: "p1", Operation: "subtract", Quantity: 2}, // 6 -> 4
+	}); err != nil {
+		t.Fatalf("BatchUpdateStock: %v", err)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Stock != 4 {
+		t.Fatalf("batch published %d events, want one at stock 4", len(publisher.events))
+	}
+
+	// A product without a threshold never alerts.
+	repo.product = dto.ProductDTO{ID: "p2", Stock: 3}
+	publisher.events = nil
+	if _, err := uc.UpdateStock(ctx, &dto.UpdateStockDTO{ID: "p2", Operation: "subtract", Quantity: 3}); err != nil {
+		t.Fatalf("UpdateStock: %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Fatalf("published %d events for a product without a threshold", len(publisher.events))
+	}
+}
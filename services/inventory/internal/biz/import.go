@@ -0,0 +1,170 @@
+package biz
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+	"github.com/reverny/kratos-mono/services/inventory/internal/productfile"
+)
+
+const (
+	// maxImportErrors caps the row errors returned by ImportProducts; the
+	// Failed count still covers every row.
+	maxImportErrors = 1000
+	// exportPageSize is the page size ExportProducts reads products with.
+	exportPageSize = 100
+)
+
+// ImportProducts upserts the records of r by SKU. New SKUs are created with
+// their stock at the default location; existing products get only the
+// provided columns updated and keep their stock. Each row stands alone: a
+// failing row is reported and the import moves on. A dry run validates and
+// reports without writing.
+func (uc *InventoryUsecase) ImportProducts(ctx context.Context, r *productfile.Reader, opts *dto.ImportProductsDTO) (*dto.ImportResultDTO, error) {
+	uc.log.WithContext(ctx).Infof("ImportProducts: dry_run=%v", opts.DryRun)
+
+	result := &dto.ImportResultDTO{DryRun: opts.DryRun}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		var rowErr *productfile.RowError
+		if errors.As(err, &rowErr) {
+			result.TotalRows++
+			uc.importFailed(result, rowErr.Row, rowErr.SKU, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), rowErr.Err.Error()))
+			continue
+		}
+		if err != nil {
+			return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), err.Error())
+		}
+
+		result.TotalRows++
+		created, err := uc.importRecord(ctx, rec, opts)
+		switch {
+		case err != nil:
+			uc.importFailed(result, rec.Row, rec.SKU, err)
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	uc.log.WithContext(ctx).Infof("ImportProducts: %d rows, %d created, %d updated, %d failed",
+		result.TotalRows, result.Created, result.Updated, result.Failed)
+	return result, nil
+}
+
+// importRecord applies one record and reports whether it created a product.
+func (uc *InventoryUsecase) importRecord(ctx context.Context, rec *dto.ProductRecordDTO, opts *dto.ImportProductsDTO) (bool, error) {
+	if rec.SKU == "" {
+		return false, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "sku is required")
+	}
+
+	existing, err := uc.repo.GetProductBySku(ctx, rec.SKU)
+	if errors.Is(err, ErrProductNotFound) {
+		if rec.Stock < 0 {
+			return false, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "stock cannot be negative")
+		}
+		req := &dto.CreateProductDTO{
+			Name:             rec.Name,
+			Description:      rec.Description,
+			SKU:              rec.SKU,
+			Price:            rec.Price,
+			Stock:            rec.Stock,
+			ReorderThreshold: rec.ReorderThreshold,
+			Actor:            opts.Actor,
+		}
+		if err := prepareCreateProduct(req); err != nil {
+			return false, err
+		}
+		if opts.DryRun {
+			return true, nil
+		}
+		_, err := uc.createProduct(ctx, req)
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Stock only seeds new products; it changes through UpdateStock.
+	var mask []string
+	for _, field := range rec.Fields {
+		if field != dto.ProductFieldStock {
+			mask = append(mask, field)
+		}
+	}
+	if len(mask) == 0 {
+		return false, nil
+	}
+	req := &dto.UpdateProductDTO{
+		ID:               existing.ID,
+		Name:             rec.Name,
+		Description:      rec.Description,
+		Price:            rec.Price,
+		ReorderThreshold: &rec.ReorderThreshold,
+		UpdateMask:       mask,
+	}
+	if err := prepareUpdateProduct(req); err != nil {
+		return false, err
+	}
+	if opts.DryRun {
+		return false, nil
+	}
+	_, err = uc.updateProduct(ctx, req)
+	return false, err
+}
+
+func (uc *InventoryUsecase) importFailed(result *dto.ImportResultDTO, row int32, sku string, err error) {
+	result.Failed++
+	if len(result.Errors) >= maxImportErrors {
+		return
+	}
+
+	message := "internal error"
+	if se := new(errors.Error); errors.As(err, &se) {
+		message = se.Message
+	} else {
+		uc.log.Errorf("import row %d (%s): %v", row, sku, err)
+	}
+	result.Errors = append(result.Errors, &dto.ImportRowErrorDTO{Row: row, SKU: sku, Message: message})
+}
+
+// ExportProducts passes every Product, ordered by SKU, to fn.
+func (uc *InventoryUsecase) ExportProducts(ctx context.Context, query *dto.ExportProductsQuery, fn func(*dto.ProductDTO) error) error {
+	uc.log.WithContext(ctx).Infof("ExportProducts: include_deleted=%v", query.IncludeDeleted)
+
+	// Only the first page uses Page; the rest follow the page token.
+	page := &dto.ListProductsQuery{
+		Page:           1,
+		PageSize:       exportPageSize,
+		SkipTotal:      true,
+		OrderBy:        dto.ProductSortSKU,
+		IncludeDeleted: query.IncludeDeleted,
+	}
+	for {
+		products, pageInfo, err := uc.repo.ListProducts(ctx, page)
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+		if pageInfo.NextPageToken == "" {
+			return nil
+		}
+		page.PageToken = pageInfo.NextPageToken
+	}
+}
@@ -0,0 +1,83 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// pagedRepo serves ListProducts from a fixed slice and rejects the queries
+// MySQL would reject.
+type pagedRepo struct {
+	InventoryRepo
+	products []*dto.ProductDTO
+	queries  []dto.ListProductsQuery
+}
+
+func (r *pagedRepo) ListProducts(_ context.Context, q *dto.ListProductsQuery) ([]*dto.ProductDTO, *dto.PageDTO, error) {
+	r.queries = append(r.queries, *q)
+
+	start := 0
+	if q.PageToken != "" {
+		var err error
+		if start, err = strconv.Atoi(q.PageToken); err != nil {
+			return nil, nil, err
+		}
+	} else if offset := (q.Page - 1) * q.PageSize; offset < 0 {
+		return nil, nil, fmt.Errorf("negative OFFSET %d", offset)
+	} else {
+		start = int(offset)
+	}
+	if q.PageSize <= 0 {
+		return nil, nil, fmt.Errorf("LIMIT %d", q.PageSize)
+	}
+
+	end := min(start+int(q.PageSize), len(r.products))
+	page := &dto.PageDTO{}
+	if end < len(r.products) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	return r.products[start:end], page, nil
+}
+
+func TestExportProductsReadsEveryPage(t *testing.T) {
+	repo := &pagedRepo{}
+	for i := 0; i < 2*exportPageSize+5; i++ {
+		repo.products = append(repo.products, &dto.ProductDTO{ID: strconv.Itoa(i), SKU: fmt.Sprintf("SKU-%03d", i)})
+	}
+	uc := NewInventoryUsecase(repo, nil, nil, log.DefaultLogger)
+
+	var exported []string
+	err := uc.ExportProducts(context.Background(), &dto.ExportProductsQuery{}, func(p *dto.ProductDTO) error {
+		exported = append(exported, p.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportProducts: %v", err)
+	}
+
+	if len(exported) != len(repo.products) {
+		t.Fatalf("exported %d products, want %d", len(exported), len(repo.products))
+	}
+	for i, id := range exported {
+		if id != repo.products[i].ID {
+			t.Fatalf("product %d: got %s, want %s", i, id, repo.products[i].ID)
+		}
+	}
+	if len(repo.queries) != 3 {
+		t.Fatalf("listed %d pages, want 3", len(repo.queries))
+	}
+	for i, q := range repo.queries {
+		if !q.SkipTotal {
+			t.Errorf("page %d counted the total", i)
+		}
+		if q.OrderBy != dto.ProductSortSKU {
+			t.Errorf("page %d ordered by %q, want %q", i, q.OrderBy, dto.ProductSortSKU)
+		}
+	}
+}
@@ -3,43 +3,97 @@ package biz
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/pkg/errcode"
+	"github.com/reverny/kratos-mono/pkg/fieldmask"
+	"github.com/reverny/kratos-mono/pkg/money"
 	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
 )
 
+var (
+	// ErrProductNotFound is returned when no product exists for the given ID.
+	ErrProductNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "product not found")
+	// ErrVersionConflict is returned when the expected version no longer matches the stored product.
+	ErrVersionConflict = errors.Conflict(common.ErrorCode_ABORTED.String(), "product was modified concurrently")
+	// ErrInsufficientStock is returned when a stock change would drive stock below zero.
+	ErrInsufficientStock = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "insufficient stock")
+	// ErrSKUAlreadyExists is returned when another product already uses the SKU.
+	ErrSKUAlreadyExists = errcode.AlreadyExists("sku already exists")
+)
+
+// maxBatchItems caps the number of lines accepted by BatchUpdateStock.
+const maxBatchItems = 1000
+
 // InventoryRepo is a Inventory repo.
 type InventoryRepo interface {
 	CreateProduct(context.Context, *dto.CreateProductDTO) (*dto.ProductDTO, error)
 	GetProduct(context.Context, string) (*dto.ProductDTO, error)
-	ListProducts(context.Context, *dto.ListProductsQuery) ([]*dto.ProductDTO, int32, error)
+	GetProductBySku(context.Context, string) (*dto.ProductDTO, error)
+	GetProductByCatalogID(context.Context, int64) (*dto.ProductDTO, error)
+	ListProducts(context.Context, *dto.ListProductsQuery) ([]*dto.ProductDTO, *dto.PageDTO, error)
+	ListLowStockProducts(context.Context, *dto.ListLowStockProductsQuery) ([]*dto.ProductDTO, int32, error)
 	UpdateProduct(context.Context, *dto.UpdateProductDTO) (*dto.ProductDTO, error)
 	DeleteProduct(context.Context, string) error
+	RestoreProduct(context.Context, string) (*dto.ProductDTO, error)
+	PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error)
 	UpdateStock(context.Context, *dto.UpdateStockDTO) (*dto.ProductDTO, error)
+	BatchUpdateStock(context.Context, []*dto.UpdateStockDTO) ([]*dto.ProductDTO, error)
+	TransferStock(context.Context, *dto.TransferStockDTO) (*dto.ProductDTO, error)
+	ListStockMovements(context.Context, *dto.ListStockMovementsQuery) ([]*dto.StockMovementDTO, int32, error)
+	ListVariants(ctx context.Context, parentID string) ([]*dto.ProductDTO, error)
 }
 
 // InventoryUsecase is a Inventory usecase.
 type InventoryUsecase struct {
-	repo InventoryRepo
-	log  *log.Helper
+	repo      InventoryRepo
+	catalog   Catalog
+	publisher EventPublisher
+	log       *log.Helper
 }
 
 // NewInventoryUsecase new a Inventory usecase.
-func NewInventoryUsecase(repo InventoryRepo, logger log.Logger) *InventoryUsecase {
-	return &InventoryUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewInventoryUsecase(repo InventoryRepo, catalog Catalog, publisher EventPublisher, logger log.Logger) *InventoryUsecase {
+	return &InventoryUsecase{repo: repo, catalog: catalog, publisher: publisher, log: log.NewHelper(logger)}
 }
 
 // CreateProduct creates a Product.
 func (uc *InventoryUsecase) CreateProduct(ctx context.Context, req *dto.CreateProductDTO) (*dto.ProductDTO, error) {
 	uc.log.WithContext(ctx).Infof("CreateProduct: %v", req.Name)
-	
+
 	// Business logic here (validation, business rules, etc.)
+	if err := prepareCreateProduct(req); err != nil {
+		return nil, err
+	}
+
+	return uc.createProduct(ctx, req)
+}
+
+// prepareCreateProduct validates req and fills in its defaults.
+func prepareCreateProduct(req *dto.CreateProductDTO) error {
+	req.SKU = strings.TrimSpace(req.SKU)
+	if req.SKU == "" {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "sku is required")
+	}
+	if err := validatePrice(&req.Price); err != nil {
+		return err
+	}
+	if req.ReorderThreshold < 0 {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "reorder threshold cannot be negative")
+	}
 	if req.Stock < 0 {
 		req.Stock = 0
 	}
-	
-	return uc.repo.CreateProduct(ctx, req)
+	if req.LocationID == "" {
+		req.LocationID = dto.DefaultLocationID
+	}
+	return validateAttributes(req.Attributes)
 }
 
 // GetProduct gets a Product by ID.
@@ -48,12 +102,20 @@ func (uc *InventoryUsecase) GetProduct(ctx context.Context, id string) (*dto.Pro
 	return uc.repo.GetProduct(ctx, id)
 }
 
+// GetProductBySku gets a Product by its SKU.
+func (uc *InventoryUsecase) GetProductBySku(ctx context.Context, sku string) (*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("GetProductBySku: %v", sku)
+	return uc.repo.GetProductBySku(ctx, strings.TrimSpace(sku))
+}
+
 // ListProducts lists all Products.
-func (uc *InventoryUsecase) ListProducts(ctx context.Context, query *dto.ListProductsQuery) ([]*dto.ProductDTO, int32, error) {
+func (uc *InventoryUsecase) ListProducts(ctx context.Context, query *dto.ListProductsQuery) (_ []*dto.ProductDTO, _ *dto.PageDTO, err error) {
 	uc.log.WithContext(ctx).Infof("ListProducts: page=%d, page_size=%d", query.Page, query.PageSize)
 
-	// Business logic: default pagination
-	if query.Page == 0 {
+	// Business logic: default pagination; a page token replaces the page number
+	if query.PageToken != "" {
+		query.Page = 0
+	} else if query.Page == 0 {
 		query.Page = 1
 	}
 	if query.PageSize == 0 {
@@ -63,37 +125,304 @@ func (uc *InventoryUsecase) ListProducts(ctx context.Context, query *dto.ListPro
 		query.PageSize = 100 // Max page size
 	}
 
+	// Business logic: validation
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "min_price cannot exceed max_price")
+	}
+	query.Search = strings.TrimSpace(query.Search)
+	if query.OrderBy, query.Desc, err = parseProductOrderBy(query.OrderBy); err != nil {
+		return nil, nil, err
+	}
+
 	return uc.repo.ListProducts(ctx, query)
 }
 
+// parseProductOrderBy parses an order_by value of the form "<field> [asc|desc]"
+// against the sortable product fields. An empty value sorts newest first.
+func parseProductOrderBy(orderBy string) (field string, desc bool, err error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) == 0 {
+		return dto.ProductSortCreatedAt, true, nil
+	}
+	if len(parts) > 2 {
+		return "", false, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("invalid order_by: %q", orderBy))
+	}
+
+	switch parts[0] {
+	case dto.ProductSortName, dto.ProductSortSKU, dto.ProductSortPrice,
+		dto.ProductSortStock, dto.ProductSortCreatedAt, dto.ProductSortUpdatedAt:
+		field = parts[0]
+	default:
+		return "", false, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("cannot order by %q", parts[0]))
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			desc = true
+		default:
+			return "", false, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("invalid order_by direction: %q", parts[1]))
+		}
+	}
+	return field, desc, nil
+}
+
+// ListLowStockProducts lists Products at or below their reorder threshold.
+func (uc *InventoryUsecase) ListLowStockProducts(ctx context.Context, query *dto.ListLowStockProductsQuery) ([]*dto.ProductDTO, int32, error) {
+	uc.log.WithContext(ctx).Infof("ListLowStockProducts: page=%d, page_size=%d", query.Page, query.PageSize)
+
+	// Business logic: default pagination
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 10
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100 // Max page size
+	}
+
+	return uc.repo.ListLowStockProducts(ctx, query)
+}
+
 // UpdateProduct updates a Product.
 func (uc *InventoryUsecase) UpdateProduct(ctx context.Context, req *dto.UpdateProductDTO) (*dto.ProductDTO, error) {
 	uc.log.WithContext(ctx).Infof("UpdateProduct: %v", req.ID)
-	
+
 	// Business logic: validation
-	if req.Price < 0 {
-		return nil, fmt.Errorf("price cannot be negative")
+	if err := prepareUpdateProduct(req); err != nil {
+		return nil, err
+	}
+
+	return uc.updateProduct(ctx, req)
+}
+
+// prepareUpdateProduct validates req and resolves its update mask.
+func prepareUpdateProduct(req *dto.UpdateProductDTO) error {
+	if req.ReorderThreshold != nil && *req.ReorderThreshold < 0 {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "reorder threshold cannot be negative")
+	}
+
+	// Business logic: only masked fields are written. Without a mask the
+	// request replaces name, description and price as it always has.
+	for i, path := range req.UpdateMask {
+		if path == "unit_price" {
+			req.UpdateMask[i] = dto.ProductFieldPrice
+		}
+	}
+	mask, err := fieldmask.Validate(req.UpdateMask, dto.ProductFieldName, dto.ProductFieldDescription,
+		dto.ProductFieldPrice, dto.ProductFieldReorderThreshold, dto.ProductFieldAttributes)
+	if err != nil {
+		return err
+	}
+	if len(mask) == 0 {
+		mask = []string{dto.ProductFieldName, dto.ProductFieldDescription, dto.ProductFieldPrice}
+		if req.ReorderThreshold != nil {
+			mask = append(mask, dto.ProductFieldReorderThreshold)
+		}
+	} else if fieldmask.Has(mask, dto.ProductFieldReorderThreshold) && req.ReorderThreshold == nil {
+		// A masked but absent threshold clears it.
+		req.ReorderThreshold = new(int32)
+	}
+	req.UpdateMask = mask
+	if fieldmask.Has(mask, dto.ProductFieldAttributes) {
+		if err := validateAttributes(req.Attributes); err != nil {
+			return err
+		}
+	}
+	if fieldmask.Has(mask, dto.ProductFieldPrice) {
+		return validatePrice(&req.Price)
+	}
+	return nil
+}
+
+// Prices are also stored in the DECIMAL(15, 2) price column that
+// ListProducts filters and sorts on. Finer or larger amounts would be
+// rounded there and filter and sort unlike their exact value, so they are
+// rejected.
+const (
+	maxPriceUnits = 9_999_999_999_999 // 13 integer digits
+	centNanos     = 10_000_000
+)
+
+// validatePrice defaults the currency and rejects malformed or negative
+// prices and prices the price column cannot hold exactly.
+func validatePrice(price *dto.Money) error {
+	if price.CurrencyCode == "" {
+		price.CurrencyCode = dto.DefaultCurrency
+	}
+	if err := money.Validate(price.CurrencyCode, price.Units, price.Nanos); err != nil {
+		return err
+	}
+	if price.Units < 0 || price.Nanos < 0 {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "price cannot be negative")
 	}
-	
-	return uc.repo.UpdateProduct(ctx, req)
+	if price.Nanos%centNanos != 0 {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "price cannot have more than two decimal places")
+	}
+	if price.Units > maxPriceUnits {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "price is too large")
+	}
+	return nil
 }
 
-// DeleteProduct deletes a Product.
+// DeleteProduct soft-deletes a Product; it can be restored until purged.
 func (uc *InventoryUsecase) DeleteProduct(ctx context.Context, id string) error {
 	uc.log.WithContext(ctx).Infof("DeleteProduct: %v", id)
 	return uc.repo.DeleteProduct(ctx, id)
 }
 
+// RestoreProduct restores a soft-deleted Product.
+func (uc *InventoryUsecase) RestoreProduct(ctx context.Context, id string) (*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("RestoreProduct: %v", id)
+	return uc.repo.RestoreProduct(ctx, id)
+}
+
+// PurgeDeletedProducts permanently removes Products deleted longer ago than
+// retention and reports how many were removed.
+func (uc *InventoryUsecase) PurgeDeletedProducts(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+	return uc.repo.PurgeDeletedProducts(ctx, time.Now().UTC().Add(-retention))
+}
+
 // UpdateStock updates product stock.
 func (uc *InventoryUsecase) UpdateStock(ctx context.Context, req *dto.UpdateStockDTO) (*dto.ProductDTO, error) {
 	uc.log.WithContext(ctx).Infof("UpdateStock: id=%s, quantity=%d, operation=%s", req.ID, req.Quantity, req.Operation)
-	
-	// Business logic: validate operation
+
+	if err := validateStockUpdate(req); err != nil {
+		return nil, err
+	}
+
+	productDTO, err := uc.repo.UpdateStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyLowStock(ctx, req, productDTO)
+	return productDTO, nil
+}
+
+// BatchUpdateStock applies every stock change or none of them.
+func (uc *InventoryUsecase) BatchUpdateStock(ctx context.Context, items []*dto.UpdateStockDTO) ([]*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("BatchUpdateStock: items=%d", len(items))
+
+	if len(items) == 0 {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "items must not be empty")
+	}
+	if len(items) > maxBatchItems {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(),
+			fmt.Sprintf("at most %d items are allowed per batch", maxBatchItems))
+	}
+	// Validate the whole batch before touching the database.
+	for i, item := range items {
+		if err := validateStockUpdate(item); err != nil {
+			return nil, BatchItemError(i, item.ID, err)
+		}
+	}
+
+	products, err := uc.repo.BatchUpdateStock(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range products {
+		uc.notifyLowStock(ctx, items[i], p)
+	}
+	return products, nil
+}
+
+// notifyLowStock publishes a low-stock event when req took the product from
+// above its reorder threshold to at or below it. The stock change has
+// already committed, so publishing failures are only logged.
+func (uc *InventoryUsecase) notifyLowStock(ctx context.Context, req *dto.UpdateStockDTO, p *dto.ProductDTO) {
+	if req.Operation != "subtract" || p.ReorderThreshold <= 0 {
+		return
+	}
+	if p.Stock > p.ReorderThreshold || p.Stock+req.Quantity <= p.ReorderThreshold {
+		return
+	}
+	event := &dto.LowStockEventDTO{
+		ProductID:        p.ID,
+		SKU:              p.SKU,
+		Name:             p.Name,
+		Stock:            p.Stock,
+		ReorderThreshold: p.ReorderThreshold,
+		OccurredAt:       p.UpdatedAt,
+	}
+	if err := uc.publisher.PublishLowStock(ctx, event); err != nil {
+		uc.log.WithContext(ctx).Errorf("publish low stock event for product %s: %v", p.ID, err)
+	}
+}
+
+// validateStockUpdate checks a single stock change and fills in defaults.
+func validateStockUpdate(req *dto.UpdateStockDTO) error {
 	if req.Operation != "add" && req.Operation != "subtract" {
-		return nil, fmt.Errorf("invalid operation: %s", req.Operation)
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("invalid operation: %s", req.Operation))
+	}
+	if req.Quantity <= 0 {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "quantity must be positive")
 	}
-	
-	return uc.repo.UpdateStock(ctx, req)
+	if req.Reason == "" {
+		req.Reason = dto.MovementReasonAdjustment
+	}
+	if req.LocationID == "" {
+		req.LocationID = dto.DefaultLocationID
+	}
+	return nil
+}
+
+// TransferStock moves stock of a Product from one location to another.
+func (uc *InventoryUsecase) TransferStock(ctx context.Context, req *dto.TransferStockDTO) (*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("TransferStock: product_id=%s, quantity=%d, from=%s, to=%s", req.ProductID, req.Quantity, req.FromLocationID, req.ToLocationID)
+
+	if req.Quantity <= 0 {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "quantity must be positive")
+	}
+	if req.FromLocationID == "" {
+		req.FromLocationID = dto.DefaultLocationID
+	}
+	if req.ToLocationID == "" {
+		req.ToLocationID = dto.DefaultLocationID
+	}
+	if req.FromLocationID == req.ToLocationID {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "from and to locations must differ")
+	}
+
+	return uc.repo.TransferStock(ctx, req)
+}
+
+// BatchItemError tags err with the position and product of the failing batch
+// item. Errors without a status are returned unchanged.
+func BatchItemError(index int, id string, err error) error {
+	se := new(errors.Error)
+	if !errors.As(err, &se) {
+		return err
+	}
+	md := map[string]string{"index": strconv.Itoa(index), "id": id}
+	for k, v := range se.Metadata {
+		md[k] = v
+	}
+	return se.WithMetadata(md)
 }
 
+// ListStockMovements lists the stock ledger of a Product, oldest first.
+func (uc *InventoryUsecase) ListStockMovements(ctx context.Context, query *dto.ListStockMovementsQuery) ([]*dto.StockMovementDTO, int32, error) {
+	uc.log.WithContext(ctx).Infof("ListStockMovements: product_id=%s, page=%d, page_size=%d", query.ProductID, query.Page, query.PageSize)
 
+	if !query.StartTime.IsZero() && !query.EndTime.IsZero() && !query.StartTime.Before(query.EndTime) {
+		return nil, 0, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "start_time must be before end_time")
+	}
+
+	// Business logic: default pagination
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = 10
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100 // Max page size
+	}
+
+	return uc.repo.ListStockMovements(ctx, query)
+}
@@ -0,0 +1,38 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestValidatePrice(t *testing.T) {
+	cases := []struct {
+		name  string
+		price dto.Money
+		ok    bool
+	}{
+		{"zero", dto.Money{}, true},
+		{"cents", dto.Money{Units: 19, Nanos: 990000000}, true},
+		{"largest", dto.Money{Units: maxPriceUnits, Nanos: 990000000}, true},
+		{"sub-cent", dto.Money{Units: 19, Nanos: 995000000}, false},
+		{"one nano", dto.Money{Nanos: 1}, false},
+		{"too large", dto.Money{Units: maxPriceUnits + 1}, false},
+		{"negative", dto.Money{Units: -1}, false},
+		{"bad currency", dto.Money{CurrencyCode: "thb", Units: 1}, false},
+		{"mixed signs", dto.Money{Units: 1, Nanos: -500000000}, false},
+	}
+	for _, c := range cases {
+		price := c.price
+		err := validatePrice(&price)
+		if c.ok && (err != nil || price.CurrencyCode != dto.DefaultCurrency) {
+			t.Errorf("%s: %v, currency %q", c.name, err, price.CurrencyCode)
+		}
+		if !c.ok && errors.Reason(err) != common.ErrorCode_INVALID_ARGUMENT.String() {
+			t.Errorf("%s: got %v, want INVALID_ARGUMENT", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/pkg/errcode"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+var (
+	// ErrLocationNotFound is returned when no location exists for the given ID.
+	ErrLocationNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "location not found")
+	// ErrLocationCodeExists is returned when another location already uses the code.
+	ErrLocationCodeExists = errcode.AlreadyExists("location code already exists")
+)
+
+// LocationRepo is a stock location repo.
+type LocationRepo interface {
+	CreateLocation(context.Context, *dto.CreateLocationDTO) (*dto.LocationDTO, error)
+	ListLocations(context.Context) ([]*dto.LocationDTO, error)
+}
+
+// LocationUsecase manages the warehouses stock is held in.
+type LocationUsecase struct {
+	repo LocationRepo
+	log  *log.Helper
+}
+
+// NewLocationUsecase new a Location usecase.
+func NewLocationUsecase(repo LocationRepo, logger log.Logger) *LocationUsecase {
+	return &LocationUsecase{repo: repo, log: log.NewHelper(logger)}
+}
+
+// CreateLocation creates a Location.
+func (uc *LocationUsecase) CreateLocation(ctx context.Context, req *dto.CreateLocationDTO) (*dto.LocationDTO, error) {
+	uc.log.WithContext(ctx).Infof("CreateLocation: %v", req.Code)
+
+	req.Code = strings.TrimSpace(req.Code)
+	if req.Code == "" {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "code is required")
+	}
+	if req.Name == "" {
+		req.Name = req.Code
+	}
+
+	return uc.repo.CreateLocation(ctx, req)
+}
+
+// ListLocations lists all Locations.
+func (uc *LocationUsecase) ListLocations(ctx context.Context) ([]*dto.LocationDTO, error) {
+	uc.log.WithContext(ctx).Info("ListLocations")
+	return uc.repo.ListLocations(ctx)
+}
@@ -0,0 +1,73 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// defaultReservationTTL applies when conf.Inventory.ReservationTtl is unset.
+const defaultReservationTTL = 15 * time.Minute
+
+var (
+	// ErrReservationNotFound is returned when no reservation exists for the given ID.
+	ErrReservationNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "reservation not found")
+	// ErrReservationNotActive is returned when a reservation was already committed, released or has expired.
+	ErrReservationNotActive = errors.Conflict(common.ErrorCode_ABORTED.String(), "reservation is no longer active")
+)
+
+// ReservationRepo is a stock reservation repo.
+type ReservationRepo interface {
+	ReserveStock(context.Context, *dto.ReserveStockDTO) (*dto.ReservationDTO, error)
+	CommitReservation(context.Context, *dto.CommitReservationDTO) (*dto.ReservationDTO, error)
+	ReleaseReservation(context.Context, string) (*dto.ReservationDTO, error)
+}
+
+// ReservationUsecase holds stock for checkout flows until it is committed or released.
+type ReservationUsecase struct {
+	repo ReservationRepo
+	ttl  time.Duration
+	log  *log.Helper
+}
+
+// NewReservationUsecase new a Reservation usecase.
+func NewReservationUsecase(repo ReservationRepo, c *conf.Inventory, logger log.Logger) *ReservationUsecase {
+	ttl := c.GetReservationTtl().AsDuration()
+	if ttl <= 0 {
+		ttl = defaultReservationTTL
+	}
+	return &ReservationUsecase{repo: repo, ttl: ttl, log: log.NewHelper(logger)}
+}
+
+// ReserveStock holds quantity of a product for the configured TTL.
+func (uc *ReservationUsecase) ReserveStock(ctx context.Context, req *dto.ReserveStockDTO) (*dto.ReservationDTO, error) {
+	uc.log.WithContext(ctx).Infof("ReserveStock: product_id=%s, quantity=%d, reference_id=%s", req.ProductID, req.Quantity, req.ReferenceID)
+
+	if req.Quantity <= 0 {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "quantity must be positive")
+	}
+	req.TTL = uc.ttl
+
+	return uc.repo.ReserveStock(ctx, req)
+}
+
+// CommitReservation deducts the reserved quantity from on-hand stock.
+func (uc *ReservationUsecase) CommitReservation(ctx context.Context, req *dto.CommitReservationDTO) (*dto.ReservationDTO, error) {
+	uc.log.WithContext(ctx).Infof("CommitReservation: %v", req.ID)
+	if req.LocationID == "" {
+		req.LocationID = dto.DefaultLocationID
+	}
+	return uc.repo.CommitReservation(ctx, req)
+}
+
+// ReleaseReservation returns the reserved quantity to available stock.
+func (uc *ReservationUsecase) ReleaseReservation(ctx context.Context, id string) (*dto.ReservationDTO, error) {
+	uc.log.WithContext(ctx).Infof("ReleaseReservation: %v", id)
+	return uc.repo.ReleaseReservation(ctx, id)
+}
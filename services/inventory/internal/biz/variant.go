@@ -0,0 +1,87 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+var (
+	// ErrNestedVariant is returned when a variant is created under another variant.
+	ErrNestedVariant = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "variants cannot have variants")
+	// ErrProductHasVariants is returned when deleting a product whose variants are still live.
+	ErrProductHasVariants = errors.Conflict(common.ErrorCode_ABORTED.String(), "product has variants; delete them first")
+	// ErrDuplicateAttribute is returned when a product names the same attribute twice.
+	ErrDuplicateAttribute = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "duplicate attribute name")
+)
+
+const (
+	// maxAttributes caps the attributes of a single product.
+	maxAttributes = 50
+	// maxAttributeNameLen matches product_attributes.name.
+	maxAttributeNameLen = 64
+)
+
+// CreateVariant creates a Product as a variant of req.ParentID.
+func (uc *InventoryUsecase) CreateVariant(ctx context.Context, req *dto.CreateProductDTO) (*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("CreateVariant: %v of %v", req.SKU, req.ParentID)
+
+	if req.ParentID == "" {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "product_id is required")
+	}
+
+	return uc.CreateProduct(ctx, req)
+}
+
+// ListVariants lists the variants of a Product.
+func (uc *InventoryUsecase) ListVariants(ctx context.Context, parentID string) ([]*dto.ProductDTO, error) {
+	uc.log.WithContext(ctx).Infof("ListVariants: %v", parentID)
+	return uc.repo.ListVariants(ctx, parentID)
+}
+
+// validateAttributes trims attribute names and checks that each is unique
+// and that every value parses as its declared type.
+func validateAttributes(attributes []*dto.AttributeDTO) error {
+	if len(attributes) > maxAttributes {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("at most %d attributes are allowed", maxAttributes))
+	}
+
+	seen := make(map[string]bool, len(attributes))
+	for _, a := range attributes {
+		a.Name = strings.TrimSpace(a.Name)
+		if a.Name == "" {
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "attribute name is required")
+		}
+		if utf8.RuneCountInString(a.Name) > maxAttributeNameLen {
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("attribute name %q is too long", a.Name))
+		}
+		if seen[a.Name] {
+			return ErrDuplicateAttribute.WithMetadata(map[string]string{"name": a.Name})
+		}
+		seen[a.Name] = true
+
+		var err error
+		switch a.Type {
+		case dto.AttributeString:
+		case dto.AttributeInt:
+			_, err = strconv.ParseInt(a.Value, 10, 64)
+		case dto.AttributeDouble:
+			_, err = strconv.ParseFloat(a.Value, 64)
+		case dto.AttributeBool:
+			_, err = strconv.ParseBool(a.Value)
+		default:
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("attribute %q has no value", a.Name))
+		}
+		if err != nil {
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), fmt.Sprintf("attribute %q is not a valid %s", a.Name, a.Type))
+		}
+	}
+	return nil
+}
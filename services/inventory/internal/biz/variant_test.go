@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestValidateAttributes(t *testing.T) {
+	attr := func(name, typ, value string) *dto.AttributeDTO {
+		return &dto.AttributeDTO{Name: name, Type: typ, Value: value}
+	}
+	tooMany := make([]*dto.AttributeDTO, maxAttributes+1)
+	for i := range tooMany {
+		tooMany[i] = attr(strings.Repeat("a", i+1), dto.AttributeString, "")
+	}
+
+	cases := []struct {
+		name       string
+		attributes []*dto.AttributeDTO
+		ok         bool
+	}{
+		{"none", nil, true},
+		{"every type", []*dto.AttributeDTO{
+			attr("color", dto.AttributeString, "red"),
+			attr("size", dto.AttributeInt, "42"),
+			attr("weight", dto.AttributeDouble, "1.5"),
+			attr("organic", dto.AttributeBool, "true"),
+		}, true},
+		{"blank name", []*dto.AttributeDTO{attr("  ", dto.AttributeString, "x")}, false},
+		{"long name", []*dto.AttributeDTO{attr(strings.Repeat("ก", maxAttributeNameLen+1), dto.AttributeString, "x")}, false},
+		{"no value", []*dto.AttributeDTO{attr("color", "", "")}, false},
+		{"bad int", []*dto.AttributeDTO{attr("size", dto.AttributeInt, "4.2")}, false},
+		{"bad double", []*dto.AttributeDTO{attr("weight", dto.AttributeDouble, "heavy")}, false},
+		{"bad bool", []*dto.AttributeDTO{attr("organic", dto.AttributeBool, "maybe")}, false},
+		{"too many", tooMany, false},
+	}
+	for _, c := range cases {
+		err := validateAttributes(c.attributes)
+		if c.ok && err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+		if !c.ok && errors.Reason(err) != common.ErrorCode_INVALID_ARGUMENT.String() {
+			t.Errorf("%s: got %v, want INVALID_ARGUMENT", c.name, err)
+		}
+	}
+}
+
+func TestValidateAttributesTrimsAndRejectsDuplicates(t *testing.T) {
+	attributes := []*dto.AttributeDTO{{Name: " color ", Type: dto.AttributeString, Value: "red"}}
+	if err := validateAttributes(attributes); err != nil || attributes[0].Name != "color" {
+		t.Fatalf("got name %q, %v; want a trimmed name", attributes[0].Name, err)
+	}
+
+	attributes = append(attributes, &dto.AttributeDTO{Name: "color\t", Type: dto.AttributeString, Value: "blue"})
+	if err := validateAttributes(attributes); !errors.Is(err, ErrDuplicateAttribute) {
+		t.Fatalf("got %v, want ErrDuplicateAttribute", err)
+	}
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+
+	productv1 "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+)
+
+// catalogClient is the biz.Catalog backed by the product service.
+type catalogClient struct {
+	client productv1.ProductClient
+	log    *log.Helper
+}
+
+// NewCatalog connects to the product service at c.Catalog.Endpoint. Without
+// an endpoint products are stored without a catalog link.
+func NewCatalog(c *conf.Data, logger log.Logger) (biz.Catalog, func(), error) {
+	helper := log.NewHelper(logger)
+
+	endpoint := c.GetCatalog().GetEndpoint()
+	if endpoint == "" {
+		helper.Warn("catalog endpoint not configured; products will not be linked to the catalog")
+		return unlinkedCatalog{}, func() {}, nil
+	}
+
+	opts := []grpc.ClientOption{
+		grpc.WithEndpoint(endpoint),
+		// Calls are made on behalf of the user being served.
+		grpc.WithMiddleware(auth.Client()),
+	}
+	if timeout := c.GetCatalog().GetTimeout(); timeout != nil {
+		opts = append(opts, grpc.WithTimeout(timeout.AsDuration()))
+	}
+	conn, err := grpc.DialInsecure(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dial catalog %s: %w", endpoint, err)
+	}
+
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			helper.Error(err)
+		}
+	}
+	return &catalogClient{client: productv1.NewProductClient(conn), log: helper}, cleanup, nil
+}
+
+func (c *catalogClient) ResolveProduct(ctx context.Context, sku, name string) (int64, error) {
+	reply, err := c.client.GetProductBySku(ctx, &productv1.GetProductBySkuRequest{Sku: sku})
+	if err == nil {
+		return reply.GetData().GetId(), nil
+	}
+	if !errors.IsNotFound(err) {
+		return 0, fmt.Errorf("catalog lookup of sku %s: %w", sku, err)
+	}
+
+	created, err := c.client.CreateProduct(ctx, &productv1.CreateProductRequest{Name: name, Sku: sku})
+	if errors.IsConflict(err) {
+		// Another writer created it first.
+		reply, err = c.client.GetProductBySku(ctx, &productv1.GetProductBySkuRequest{Sku: sku})
+		if err != nil {
+			return 0, fmt.Errorf("catalog lookup of sku %s: %w", sku, err)
+		}
+		return reply.GetData().GetId(), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("catalog create of sku %s: %w", sku, err)
+	}
+
+	c.log.Infof("Catalog product created for sku %s: %d", sku, created.GetData().GetId())
+	return created.GetData().GetId(), nil
+}
+
+func (c *catalogClient) RenameProduct(ctx context.Context, id int64, name string) error {
+	if _, err := c.client.UpdateProduct(ctx, &productv1.UpdateProductRequest{Id: id, Name: name}); err != nil {
+		return fmt.Errorf("catalog rename of product %d: %w", id, err)
+	}
+	return nil
+}
+
+// unlinkedCatalog stands in when no catalog is configured.
+type unlinkedCatalog struct{}
+
+func (unlinkedCatalog) ResolveProduct(context.Context, string, string) (int64, error) { return 0, nil }
+
+func (unlinkedCatalog) RenameProduct(context.Context, int64, string) error { return nil }
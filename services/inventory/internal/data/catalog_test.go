@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	productv1 "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/errcode"
+)
+
+// fakeProductClient is a product service holding catalog products by SKU.
+// When raced is set, CreateProduct loses to a writer that stored the SKU
+// first.
+type fakeProductClient struct {
+	productv1.ProductClient
+	bySku   map[string]*productv1.ProductItem
+	raced   bool
+	creates int
+	renamed map[int64]string
+}
+
+func (c *fakeProductClient) GetProductBySku(_ context.Context, in *productv1.GetProductBySkuRequest, _ ...grpc.CallOption) (*productv1.GetProductBySkuReply, error) {
+	item, ok := c.bySku[in.Sku]
+	if !ok {
+		return nil, errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "product not found")
+	}
+	return &productv1.GetProductBySkuReply{Data: item}, nil
+}
+
+func (c *fakeProductClient) CreateProduct(_ context.Context, in *productv1.CreateProductRequest, _ ...grpc.CallOption) (*productv1.CreateProductReply, error) {
+	c.creates++
+	item := &productv1.ProductItem{Id: int64(100 + len(c.bySku)), Name: in.Name, Sku: in.Sku}
+	c.bySku[in.Sku] = item
+	if c.raced {
+		return nil, errcode.AlreadyExists("sku already exists")
+	}
+	return &productv1.CreateProductReply{Data: item}, nil
+}
+
+func (c *fakeProductClient) UpdateProduct(_ context.Context, in *productv1.UpdateProductRequest, _ ...grpc.CallOption) (*productv1.UpdateProductReply, error) {
+	if c.renamed == nil {
+		return nil, errors.ServiceUnavailable(common.ErrorCode_INTERNAL.String(), "catalog unavailable")
+	}
+	c.renamed[in.Id] = in.Name
+	return &productv1.UpdateProductReply{Data: &productv1.ProductItem{Id: in.Id, Name: in.Name}}, nil
+}
+
+func TestCatalogClientResolvesBySku(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name        string
+		existing    map[string]*productv1.ProductItem
+		raced       bool
+		wantID      int64
+		wantCreates int
+	}{
+		{"existing entry", map[string]*productv1.ProductItem{"MUG-1": {Id: 7, Sku: "MUG-1"}}, false, 7, 0},
+		{"missing entry", map[string]*productv1.ProductItem{}, false, 100, 1},
+		{"created concurrently", map[string]*productv1.ProductItem{}, true, 100, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client := &fakeProductClient{bySku: c.existing, raced: c.raced}
+			catalog := &catalogClient{client: client, log: log.NewHelper(log.DefaultLogger)}
+			id, err := catalog.ResolveProduct(ctx, "MUG-1", "Mug")
+			if err != nil {
+				t.Fatalf("ResolveProduct: %v", err)
+			}
+			if id != c.wantID || client.creates != c.wantCreates {
+				t.Fatalf("got id %d after %d creates, want %d after %d", id, client.creates, c.wantID, c.wantCreates)
+			}
+		})
+	}
+}
+
+func TestCatalogClientRename(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeProductClient{renamed: map[int64]string{}}
+	catalog := &catalogClient{client: client, log: log.NewHelper(log.DefaultLogger)}
+	if err := catalog.RenameProduct(ctx, 7, "Large mug"); err != nil || client.renamed[7] != "Large mug" {
+		t.Fatalf("RenameProduct: %v, renamed %v", err, client.renamed)
+	}
+
+	client.renamed = nil
+	if err := catalog.RenameProduct(ctx, 7, "Large mug"); !errors.IsServiceUnavailable(err) {
+		t.Fatalf("RenameProduct against an unavailable catalog: got %v", err)
+	}
+}
+
+func TestUnlinkedCatalog(t *testing.T) {
+	if id, err := (unlinkedCatalog{}).ResolveProduct(context.Background(), "MUG-1", "Mug"); id != 0 || err != nil {
+		t.Fatalf("ResolveProduct = %d, %v; want 0, nil", id, err)
+	}
+}
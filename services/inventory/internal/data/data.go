@@ -1,29 +1,67 @@
 package data
 
 import (
+	"context"
+	"embed"
+	"io/fs"
+
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/sqldb"
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
 	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewInventoryRepo)
+var ProviderSet = wire.NewSet(
+	NewData,
+	NewInventoryRepo,
+	NewReservationRepo,
+	NewLocationRepo,
+	NewCatalog,
+	NewOutboxEventPublisher,
+	wire.Bind(new(biz.EventPublisher), new(*OutboxEventPublisher)),
+	outbox.NewChannelPublisher,
+	NewOutboxRelay,
+)
+
+// migrationFS holds the versioned schema migrations applied by NewData.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
 
 // Data .
 type Data struct {
-	// TODO: Add database connection, redis connection, etc.
+	*sqldb.DB
 	log *log.Helper
 }
 
 // NewData .
 func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
-	cleanup := func() {
-		log.NewHelper(logger).Info("closing the data resources")
-	}
+	helper := log.NewHelper(logger)
 
-	data := &Data{
-		log: log.NewHelper(logger),
+	ctx := context.Background()
+	db, err := sqldb.Open(ctx, c.GetDatabase().GetDriver(), c.GetDatabase().GetSource())
+	if err != nil {
+		return nil, nil, err
+	}
+	migrations, err := fs.Sub(migrationFS, "migrations")
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	if err := db.Migrate(ctx, migrations, helper); err != nil {
+		db.Close()
+		return nil, nil, err
 	}
 
-	return data, cleanup, nil
+	cleanup := func() {
+		helper.Info("closing the data resources")
+		if err := db.Close(); err != nil {
+			helper.Error(err)
+		}
+	}
+	return &Data{DB: db, log: helper}, cleanup, nil
 }
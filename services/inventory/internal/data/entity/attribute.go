@@ -0,0 +1,21 @@
+package entity
+
+import "github.com/reverny/kratos-mono/services/inventory/internal/dto"
+
+// Attribute represents the database entity for a product attribute
+type Attribute struct {
+	ProductID string
+	Name      string
+	Type      string
+	Value     string
+	SortOrder int32
+}
+
+// ToDTO converts entity to DTO
+func (e *Attribute) ToDTO() *dto.AttributeDTO {
+	return &dto.AttributeDTO{
+		Name:  e.Name,
+		Type:  e.Type,
+		Value: e.Value,
+	}
+}
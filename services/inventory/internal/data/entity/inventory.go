@@ -3,45 +3,109 @@ package entity
 import (
 	"time"
 
+	"github.com/reverny/kratos-mono/pkg/money"
 	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
 )
 
 // Product represents the database entity for product
 type Product struct {
-	ID          string
-	Name        string
-	Description string
-	SKU         string
-	Price       float64
-	Stock       int32
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID               string
+	CatalogID        int64 // 0 until linked to the catalog service
+	Name             string
+	Description      string
+	SKU              string
+	Price            float64 // approximate copy of the price used to filter and sort
+	PriceUnits       int64
+	PriceNanos       int32
+	CurrencyCode     string
+	Stock            int32
+	Reserved         int32 // computed from active reservations, not stored
+	Version          int64
+	ReorderThreshold int32         // low stock at or below this level; 0 disables alerts
+	StockLevels      []*StockLevel // loaded separately from stock_levels
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time // nil unless soft-deleted
+	ParentID         string
+	Attributes       []*Attribute // loaded separately from product_attributes
+	Variants         []*Product   // loaded separately for parent products
 }
 
 // ToDTO converts entity to DTO
 func (e *Product) ToDTO() *dto.ProductDTO {
 	return &dto.ProductDTO{
-		ID:          e.ID,
-		Name:        e.Name,
-		Description: e.Description,
-		SKU:         e.SKU,
-		Price:       e.Price,
-		Stock:       e.Stock,
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		ID:               e.ID,
+		CatalogID:        e.CatalogID,
+		Name:             e.Name,
+		Description:      e.Description,
+		SKU:              e.SKU,
+		Price:            dto.Money{CurrencyCode: e.CurrencyCode, Units: e.PriceUnits, Nanos: e.PriceNanos},
+		Stock:            e.Stock,
+		AvailableStock:   e.Stock - e.Reserved,
+		Version:          e.Version,
+		ReorderThreshold: e.ReorderThreshold,
+		StockLevels:      stockLevelsToDTO(e.StockLevels),
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+		DeletedAt:        e.DeletedAt,
+		ParentID:         e.ParentID,
+		Attributes:       attributesToDTO(e.Attributes),
+		Variants:         productsToDTO(e.Variants),
 	}
 }
 
 // FromDTO converts DTO to entity
 func FromDTO(d *dto.ProductDTO) *Product {
 	return &Product{
-		ID:          d.ID,
-		Name:        d.Name,
-		Description: d.Description,
-		SKU:         d.SKU,
-		Price:       d.Price,
-		Stock:       d.Stock,
-		CreatedAt:   d.CreatedAt,
-		UpdatedAt:   d.UpdatedAt,
+		ID:               d.ID,
+		CatalogID:        d.CatalogID,
+		Name:             d.Name,
+		Description:      d.Description,
+		SKU:              d.SKU,
+		Price:            money.Float64(d.Price.Units, d.Price.Nanos),
+		PriceUnits:       d.Price.Units,
+		PriceNanos:       d.Price.Nanos,
+		CurrencyCode:     d.Price.CurrencyCode,
+		Stock:            d.Stock,
+		Reserved:         d.Stock - d.AvailableStock,
+		Version:          d.Version,
+		ReorderThreshold: d.ReorderThreshold,
+		CreatedAt:        d.CreatedAt,
+		UpdatedAt:        d.UpdatedAt,
+		DeletedAt:        d.DeletedAt,
+		ParentID:         d.ParentID,
 	}
 }
+
+func stockLevelsToDTO(levels []*StockLevel) []*dto.StockLevelDTO {
+	if levels == nil {
+		return nil
+	}
+	dtos := make([]*dto.StockLevelDTO, len(levels))
+	for i, level := range levels {
+		dtos[i] = level.ToDTO()
+	}
+	return dtos
+}
+
+func attributesToDTO(attributes []*Attribute) []*dto.AttributeDTO {
+	if attributes == nil {
+		return nil
+	}
+	dtos := make([]*dto.AttributeDTO, len(attributes))
+	for i, attribute := range attributes {
+		dtos[i] = attribute.ToDTO()
+	}
+	return dtos
+}
+
+func productsToDTO(products []*Product) []*dto.ProductDTO {
+	if products == nil {
+		return nil
+	}
+	dtos := make([]*dto.ProductDTO, len(products))
+	for i, product := range products {
+		dtos[i] = product.ToDTO()
+	}
+	return dtos
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// Location represents the database entity for a stock location
+type Location struct {
+	ID        string
+	Code      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ToDTO converts entity to DTO
+func (e *Location) ToDTO() *dto.LocationDTO {
+	return &dto.LocationDTO{
+		ID:        e.ID,
+		Code:      e.Code,
+		Name:      e.Name,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+// StockLevel represents the database entity for per-location stock
+type StockLevel struct {
+	ProductID  string
+	LocationID string
+	Quantity   int32
+	UpdatedAt  time.Time
+}
+
+// ToDTO converts entity to DTO
+func (e *StockLevel) ToDTO() *dto.StockLevelDTO {
+	return &dto.StockLevelDTO{
+		LocationID: e.LocationID,
+		Quantity:   e.Quantity,
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// StockMovement represents the database entity for a stock ledger entry.
+// Rows are only ever inserted.
+type StockMovement struct {
+	ID          string
+	ProductID   string
+	Delta       int32
+	StockAfter  int32
+	Reason      string
+	ReferenceID string
+	Actor       string
+	LocationID  string
+	CreatedAt   time.Time
+}
+
+// ToDTO converts entity to DTO
+func (e *StockMovement) ToDTO() *dto.StockMovementDTO {
+	return &dto.StockMovementDTO{
+		ID:          e.ID,
+		ProductID:   e.ProductID,
+		Delta:       e.Delta,
+		StockAfter:  e.StockAfter,
+		Reason:      e.Reason,
+		ReferenceID: e.ReferenceID,
+		Actor:       e.Actor,
+		LocationID:  e.LocationID,
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// Reservation represents the database entity for a stock reservation
+type Reservation struct {
+	ID          string
+	ProductID   string
+	Quantity    int32
+	ReferenceID string
+	Status      string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Active reports whether the reservation still holds stock at t.
+func (e *Reservation) Active(t time.Time) bool {
+	return e.Status == dto.ReservationActive && e.ExpiresAt.After(t)
+}
+
+// ToDTO converts entity to DTO. Active reservations past their expiry are
+// reported as expired.
+func (e *Reservation) ToDTO() *dto.ReservationDTO {
+	status := e.Status
+	if status == dto.ReservationActive && !e.Active(time.Now()) {
+		status = dto.ReservationExpired
+	}
+	return &dto.ReservationDTO{
+		ID:          e.ID,
+		ProductID:   e.ProductID,
+		Quantity:    e.Quantity,
+		ReferenceID: e.ReferenceID,
+		Status:      status,
+		ExpiresAt:   e.ExpiresAt,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// lowStockEvent is the payload of LowStock.
+type lowStockEvent struct {
+	ProductID        string    `json:"product_id"`
+	SKU              string    `json:"sku"`
+	Name             string    `json:"name"`
+	Stock            int32     `json:"stock"`
+	ReorderThreshold int32     `json:"reorder_threshold"`
+	OccurredAt       time.Time `json:"occurred_at"`
+}
+
+// OutboxEventPublisher records inventory domain events in the outbox, from
+// where the relay delivers them with the other events of the service.
+type OutboxEventPublisher struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewOutboxEventPublisher .
+func NewOutboxEventPublisher(data *Data, logger log.Logger) *OutboxEventPublisher {
+	return &OutboxEventPublisher{data: data, log: log.NewHelper(logger)}
+}
+
+// PublishLowStock implements biz.EventPublisher.
+func (p *OutboxEventPublisher) PublishLowStock(ctx context.Context, e *dto.LowStockEventDTO) error {
+	event, err := outbox.NewEvent(eventSource, outbox.TypeLowStock, e.ProductID, &lowStockEvent{
+		ProductID:        e.ProductID,
+		SKU:              e.SKU,
+		Name:             e.Name,
+		Stock:            e.Stock,
+		ReorderThreshold: e.ReorderThreshold,
+		OccurredAt:       e.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := outbox.Append(ctx, p.data.Conn(ctx), event); err != nil {
+		return err
+	}
+
+	p.log.WithContext(ctx).Warnf("Low stock: product %s (%s) at %d, threshold %d",
+		e.ProductID, e.SKU, e.Stock, e.ReorderThreshold)
+	return nil
+}
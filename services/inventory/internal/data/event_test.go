@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// MemoryPublisher keeps published events in memory so tests can inspect
+// what was emitted.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	lowStock []*dto.LowStockEventDTO
+}
+
+// PublishLowStock implements biz.EventPublisher.
+func (p *MemoryPublisher) PublishLowStock(_ context.Context, event *dto.LowStockEventDTO) error {
+	p.mu.Lock()
+	p.lowStock = append(p.lowStock, event)
+	p.mu.Unlock()
+	return nil
+}
+
+// LowStockEvents returns the low-stock events published so far.
+func (p *MemoryPublisher) LowStockEvents() []*dto.LowStockEventDTO {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*dto.LowStockEventDTO(nil), p.lowStock...)
+}
+
+func TestUpdateStockPublishesLowStockOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+	publisher := &MemoryPublisher{}
+	uc := biz.NewInventoryUsecase(repo, nil, publisher, log.DefaultLogger)
+
+	p, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+		Name: "Mug", SKU: "MUG-1", Stock: 6, ReorderThreshold: 5, LocationID: dto.DefaultLocationID,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := uc.UpdateStock(ctx, &dto.UpdateStockDTO{ID: p.ID, Operation: "subtract", Quantity: 1}); err != nil {
+			t.Fatalf("UpdateStock: %v", err)
+		}
+	}
+
+	events := publisher.LowStockEvents()
+	if len(events) != 1 || events[0].ProductID != p.ID || events[0].Stock != 5 {
+		t.Fatalf("published %+v, want one event at stock 5", events)
+	}
+}
+
+func TestOutboxEventPublisherRecordsLowStock(t *testing.T) {
+	ctx := context.Background()
+	d := newTestData(t)
+	publisher := NewOutboxEventPublisher(d, log.DefaultLogger)
+
+	if err := publisher.PublishLowStock(ctx, &dto.LowStockEventDTO{
+		ProductID: "p1", SKU: "MUG-1", Stock: 4, ReorderThreshold: 5,
+	}); err != nil {
+		t.Fatalf("PublishLowStock: %v", err)
+	}
+
+	pending, err := outbox.NewSQLStore(d.SQL()).Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Type != outbox.TypeLowStock || pending[0].AggregateID != "p1" {
+		t.Fatalf("outbox holds %+v, want one LowStock event for p1", pending)
+	}
+	var payload lowStockEvent
+	if err := json.Unmarshal(pending[0].Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.SKU != "MUG-1" || payload.Stock != 4 || payload.ReorderThreshold != 5 {
+		t.Fatalf("payload %+v", payload)
+	}
+}
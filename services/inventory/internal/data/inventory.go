@@ -2,17 +2,35 @@ package data
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
 
+	"github.com/reverny/kratos-mono/pkg/money"
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/pagination"
+	"github.com/reverny/kratos-mono/pkg/sqldb"
 	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
 	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
 	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
 )
 
+const productColumns = `id, name, description, sku, price, stock, version, reorder_threshold, created_at, updated_at, deleted_at,
+	price_units, price_nanos, currency_code, parent_id, catalog_id`
+
+// reservedColumn sums the active reservations of the outer products row.
+// It takes the current time as its only argument.
+const reservedColumn = `(SELECT COALESCE(SUM(r.quantity), 0) FROM reservations r
+	WHERE r.product_id = products.id AND r.status = 'active' AND r.expires_at > ?)`
+
+const selectProduct = `SELECT ` + productColumns + `, ` + reservedColumn + ` FROM products`
+
 type inventoryRepo struct {
 	data *Data
 	log  *log.Helper
@@ -26,24 +44,151 @@ func NewInventoryRepo(data *Data, logger log.Logger) biz.InventoryRepo {
 	}
 }
 
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row rowScanner) (*entity.Product, error) {
+	var e entity.Product
+	if err := row.Scan(
+		&e.ID,
+		&e.Name,
+		&e.Description,
+		&e.SKU,
+		&e.Price,
+		&e.Stock,
+		&e.Version,
+		&e.ReorderThreshold,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.DeletedAt,
+		&e.PriceUnits,
+		&e.PriceNanos,
+		&e.CurrencyCode,
+		&e.ParentID,
+		&e.CatalogID,
+		&e.Reserved,
+	); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// nowUTC returns the current time at the precision stored by every supported driver.
+func nowUTC() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// notDeleted restricts a products query to rows that are not soft-deleted.
+const notDeleted = `deleted_at IS NULL`
+
+// findProduct loads a product that is not soft-deleted, locking its row when
+// called inside InTx with lock set.
+func findProduct(ctx context.Context, d *Data, id string, lock bool) (*entity.Product, error) {
+	query := selectProduct + ` WHERE id = ? AND ` + notDeleted
+	if lock {
+		query += d.ForUpdate()
+	}
+	productEntity, err := scanProduct(d.Conn(ctx).QueryRowContext(ctx, query, nowUTC(), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product %s: %w", id, err)
+	}
+	return productEntity, nil
+}
+
 func (r *inventoryRepo) CreateProduct(ctx context.Context, req *dto.CreateProductDTO) (*dto.ProductDTO, error) {
-	now := time.Now()
-	
+	now := nowUTC()
+
 	// Create entity from DTO
 	productEntity := &entity.Product{
-		ID:          uuid.New().String(),
-		Name:        req.Name,
-		Description: req.Description,
-		SKU:         req.SKU,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}
-
-	// TODO: Save entity to database
-	// r.data.db.Create(productEntity)
-	
+		ID:               uuid.New().String(),
+		Name:             req.Name,
+		Description:      req.Description,
+		SKU:              req.SKU,
+		Price:            money.Float64(req.Price.Units, req.Price.Nanos),
+		PriceUnits:       req.Price.Units,
+		PriceNanos:       req.Price.Nanos,
+		CurrencyCode:     req.Price.CurrencyCode,
+		Stock:            req.Stock,
+		Version:          1,
+		ParentID:         req.ParentID,
+		CatalogID:        req.CatalogID,
+		ReorderThreshold: req.ReorderThreshold,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		if req.ParentID != "" {
+			// Lock the parent so it cannot be deleted under the new variant.
+			parent, err := findProduct(ctx, r.data, req.ParentID, true)
+			if err != nil {
+				return err
+			}
+			if parent.ParentID != "" {
+				return biz.ErrNestedVariant
+			}
+		}
+
+		if _, err := r.data.Conn(ctx).ExecContext(ctx,
+			`INSERT INTO products (`+productColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			productEntity.ID,
+			productEntity.Name,
+			productEntity.Description,
+			productEntity.SKU,
+			productEntity.Price,
+			productEntity.Stock,
+			productEntity.Version,
+			productEntity.ReorderThreshold,
+			productEntity.CreatedAt,
+			productEntity.UpdatedAt,
+			productEntity.DeletedAt,
+			productEntity.PriceUnits,
+			productEntity.PriceNanos,
+			productEntity.CurrencyCode,
+			productEntity.ParentID,
+			productEntity.CatalogID,
+		); err != nil {
+			if sqldb.IsDuplicateKey(err) {
+				return biz.ErrSKUAlreadyExists
+			}
+			return fmt.Errorf("create product: %w", err)
+		}
+		if err := insertAttributes(ctx, r.data, productEntity, req.Attributes); err != nil {
+			return err
+		}
+		if err := recordProductEvent(ctx, r.data, outbox.TypeProductCreated, productEntity); err != nil {
+			return err
+		}
+		productEntity.StockLevels = []*entity.StockLevel{}
+		if productEntity.Stock == 0 {
+			return nil
+		}
+		if err := adjustStockLevel(ctx, r.data, productEntity.ID, req.LocationID, productEntity.Stock); err != nil {
+			return err
+		}
+		productEntity.StockLevels = append(productEntity.StockLevels, &entity.StockLevel{
+			ProductID:  productEntity.ID,
+			LocationID: req.LocationID,
+			Quantity:   productEntity.Stock,
+			UpdatedAt:  now,
+		})
+		return recordMovement(ctx, r.data, &entity.StockMovement{
+			ProductID:  productEntity.ID,
+			Delta:      productEntity.Stock,
+			Reason:     dto.MovementReasonInitialStock,
+			Actor:      req.Actor,
+			LocationID: req.LocationID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	r.log.Infof("Product created: %s", productEntity.ID)
 
 	// Convert entity back to DTO
@@ -51,127 +196,593 @@ func (r *inventoryRepo) CreateProduct(ctx context.Context, req *dto.CreateProduc
 }
 
 func (r *inventoryRepo) GetProduct(ctx context.Context, id string) (*dto.ProductDTO, error) {
-	// TODO: Get entity from database
-	// var productEntity entity.Product
-	// r.data.db.First(&productEntity, \"id = ?\", id)
-	
-	// Mock entity for now
-	productEntity := &entity.Product{
-		ID:          id,
-		Name:        "Sample Product",
-		Description: "This is a sample product",
-		SKU:         "SKU-001",
-		Price:       99.99,
-		Stock:       100,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	productEntity, err := findProduct(ctx, r.data, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadDetails(ctx, productEntity); err != nil {
+		return nil, err
+	}
+
+	// Convert entity to DTO
+	return productEntity.ToDTO(), nil
+}
+
+func (r *inventoryRepo) GetProductBySku(ctx context.Context, sku string) (*dto.ProductDTO, error) {
+	productEntity, err := scanProduct(r.data.Conn(ctx).QueryRowContext(ctx,
+		selectProduct+` WHERE live_sku = ?`, nowUTC(), sku))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product by sku %s: %w", sku, err)
+	}
+	if err := r.loadDetails(ctx, productEntity); err != nil {
+		return nil, err
+	}
+
+	// Convert entity to DTO
+	return productEntity.ToDTO(), nil
+}
+
+func (r *inventoryRepo) GetProductByCatalogID(ctx context.Context, catalogID int64) (*dto.ProductDTO, error) {
+	productEntity, err := scanProduct(r.data.Conn(ctx).QueryRowContext(ctx,
+		selectProduct+` WHERE catalog_id = ? AND `+notDeleted, nowUTC(), catalogID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product by catalog id %d: %w", catalogID, err)
+	}
+	if err := r.loadDetails(ctx, productEntity); err != nil {
+		return nil, err
 	}
 
 	// Convert entity to DTO
 	return productEntity.ToDTO(), nil
 }
 
-func (r *inventoryRepo) ListProducts(ctx context.Context, query *dto.ListProductsQuery) ([]*dto.ProductDTO, int32, error) {
-	// TODO: Get entities from database with pagination
-	// var entities []entity.Product
-	// r.data.db.Offset(int((query.Page - 1) * query.PageSize)).Limit(int(query.PageSize)).Find(&entities)
-	
-	// Mock entities for now
-	entities := []*entity.Product{
-		{
-			ID:          "1",
-			Name:        "Product 1",
-			Description: "Description 1",
-			SKU:         "SKU-001",
-			Price:       99.99,
-			Stock:       100,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "2",
-			Name:        "Product 2",
-			Description: "Description 2",
-			SKU:         "SKU-002",
-			Price:       149.99,
-			Stock:       50,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
+// productSortColumns maps the sortable dto fields to columns. Only these
+// identifiers are ever interpolated into ORDER BY.
+var productSortColumns = map[string]string{
+	dto.ProductSortName:      "name",
+	dto.ProductSortSKU:       "sku",
+	dto.ProductSortPrice:     "price",
+	dto.ProductSortStock:     "stock",
+	dto.ProductSortCreatedAt: "created_at",
+	dto.ProductSortUpdatedAt: "updated_at",
+}
+
+// likeEscaper escapes LIKE wildcards using '!', which both MySQL and SQLite
+// accept as an ESCAPE character without string-literal quirks.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// productFilter builds the WHERE conditions and their arguments for query.
+func productFilter(query *dto.ListProductsQuery) ([]string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	if !query.IncludeDeleted {
+		conds = append(conds, notDeleted)
+	}
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		conds = append(conds, `(name LIKE ? ESCAPE '!' OR sku LIKE ? ESCAPE '!')`)
+		args = append(args, pattern, pattern)
+	}
+	if query.MinPrice != nil {
+		conds = append(conds, `price >= ?`)
+		args = append(args, *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		conds = append(conds, `price <= ?`)
+		args = append(args, *query.MaxPrice)
+	}
+	if query.InStock != nil {
+		if *query.InStock {
+			conds = append(conds, `stock > 0`)
+		} else {
+			conds = append(conds, `stock <= 0`)
+		}
+	}
+	return conds, args
+}
+
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `)
+}
+
+// productSortKey formats the sort column value of e for a page token.
+func productSortKey(e *entity.Product, field string) string {
+	switch field {
+	case dto.ProductSortName:
+		return e.Name
+	case dto.ProductSortSKU:
+		return e.SKU
+	case dto.ProductSortPrice:
+		return strconv.FormatFloat(e.Price, 'g', -1, 64)
+	case dto.ProductSortStock:
+		return strconv.Itoa(int(e.Stock))
+	case dto.ProductSortUpdatedAt:
+		return e.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return e.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// parseProductSortKey is the inverse of productSortKey.
+func parseProductSortKey(field, key string) (any, error) {
+	switch field {
+	case dto.ProductSortName, dto.ProductSortSKU:
+		return key, nil
+	case dto.ProductSortPrice:
+		return strconv.ParseFloat(key, 64)
+	case dto.ProductSortStock:
+		return strconv.Atoi(key)
+	default:
+		t, err := time.Parse(time.RFC3339Nano, key)
+		return t.UTC(), err
+	}
+}
+
+// ListProducts pages with LIMIT/OFFSET, or by keyset when query.PageToken is
+// set. Either way a token for the following page is returned while more rows
+// remain.
+func (r *inventoryRepo) ListProducts(ctx context.Context, query *dto.ListProductsQuery) ([]*dto.ProductDTO, *dto.PageDTO, error) {
+	conds, args := productFilter(query)
+	filter := pagination.Fingerprint(query.Search, query.MinPrice, query.MaxPrice, query.InStock, query.OrderBy, query.Desc, query.IncludeDeleted)
+
+	column, ok := productSortColumns[query.OrderBy]
+	if !ok {
+		column = "created_at"
+	}
+	direction, keyOp := " ASC", ">"
+	if query.Desc {
+		direction, keyOp = " DESC", "<"
+	}
+
+	page := &dto.PageDTO{}
+	offset := (query.Page - 1) * query.PageSize
+	if query.PageToken == "" {
+		if !query.SkipTotal {
+			if err := r.data.Conn(ctx).QueryRowContext(ctx,
+				`SELECT COUNT(*) FROM products`+whereClause(conds), args...).Scan(&page.Total); err != nil {
+				return nil, nil, fmt.Errorf("count products: %w", err)
+			}
+		}
+	} else {
+		cursor, err := pagination.Decode(query.PageToken, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err := parseProductSortKey(query.OrderBy, cursor.Key)
+		if err != nil {
+			return nil, nil, pagination.ErrInvalidPageToken
+		}
+		// Rows after the cursor in "column direction, id ASC" order.
+		conds = append(conds, `(`+column+` `+keyOp+` ? OR (`+column+` = ? AND id > ?))`)
+		args = append(args, key, key, cursor.ID)
+		offset = 0
+	}
+
+	// The subquery placeholder in selectProduct comes first. One extra row
+	// tells whether another page follows.
+	args = append([]any{nowUTC()}, args...)
+	args = append(args, query.PageSize+1, offset)
+	rows, err := r.data.Conn(ctx).QueryContext(ctx,
+		selectProduct+whereClause(conds)+` ORDER BY `+column+direction+`, id LIMIT ? OFFSET ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list products: %w", err)
+	}
+	defer rows.Close()
+
+	var entities []*entity.Product
+	for rows.Next() {
+		productEntity, err := scanProduct(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan product: %w", err)
+		}
+		entities = append(entities, productEntity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("list products: %w", err)
+	}
+
+	if len(entities) > int(query.PageSize) {
+		entities = entities[:query.PageSize]
+		last := entities[len(entities)-1]
+		page.NextPageToken = pagination.Encode(&pagination.Cursor{
+			Key:    productSortKey(last, query.OrderBy),
+			ID:     last.ID,
+			Filter: filter,
+		})
 	}
 
 	// Convert entities to DTOs
 	dtos := make([]*dto.ProductDTO, len(entities))
-	for i, e := range entities {
-		dtos[i] = e.ToDTO()
+	for i, productEntity := range entities {
+		dtos[i] = productEntity.ToDTO()
 	}
 
-	return dtos, int32(len(dtos)), nil
+	return dtos, page, nil
 }
 
-func (r *inventoryRepo) UpdateProduct(ctx context.Context, req *dto.UpdateProductDTO) (*dto.ProductDTO, error) {
-	// TODO: Get existing entity from database
-	// var productEntity entity.Product
-	// r.data.db.First(&productEntity, \"id = ?\", req.ID)
-	
-	// Mock entity
-	productEntity := &entity.Product{
-		ID:          req.ID,
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		UpdatedAt:   time.Now(),
+func (r *inventoryRepo) ListLowStockProducts(ctx context.Context, query *dto.ListLowStockProductsQuery) ([]*dto.ProductDTO, int32, error) {
+	const lowStock = ` WHERE reorder_threshold > 0 AND stock <= reorder_threshold AND ` + notDeleted
+
+	var total int32
+	if err := r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM products`+lowStock).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count low stock products: %w", err)
+	}
+
+	// Furthest below the threshold first.
+	rows, err := r.data.Conn(ctx).QueryContext(ctx,
+		selectProduct+lowStock+` ORDER BY stock - reorder_threshold, id LIMIT ? OFFSET ?`,
+		nowUTC(), query.PageSize, (query.Page-1)*query.PageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list low stock products: %w", err)
 	}
+	defer rows.Close()
 
-	// TODO: Update entity in database
-	// r.data.db.Save(productEntity)
+	var dtos []*dto.ProductDTO
+	for rows.Next() {
+		productEntity, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan product: %w", err)
+		}
+		dtos = append(dtos, productEntity.ToDTO())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list low stock products: %w", err)
+	}
+
+	return dtos, total, nil
+}
+
+func (r *inventoryRepo) UpdateProduct(ctx context.Context, req *dto.UpdateProductDTO) (*dto.ProductDTO, error) {
+	var productEntity *entity.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var (
+			set               []string
+			args              []any
+			replaceAttributes bool
+		)
+		for _, field := range req.UpdateMask {
+			switch field {
+			case dto.ProductFieldName:
+				set, args = append(set, `name = ?`), append(args, req.Name)
+			case dto.ProductFieldDescription:
+				set, args = append(set, `description = ?`), append(args, req.Description)
+			case dto.ProductFieldPrice:
+				set = append(set, `price = ?`, `price_units = ?`, `price_nanos = ?`, `currency_code = ?`)
+				args = append(args, money.Float64(req.Price.Units, req.Price.Nanos), req.Price.Units, req.Price.Nanos, req.Price.CurrencyCode)
+			case dto.ProductFieldReorderThreshold:
+				set, args = append(set, `reorder_threshold = ?`), append(args, *req.ReorderThreshold)
+			case dto.ProductFieldCatalogID:
+				set, args = append(set, `catalog_id = ?`), append(args, req.CatalogID)
+			case dto.ProductFieldAttributes:
+				replaceAttributes = true
+			}
+		}
+		set = append(set, `version = version + 1`, `updated_at = ?`)
+		query := `UPDATE products SET ` + strings.Join(set, `, `) + ` WHERE id = ? AND ` + notDeleted
+		args = append(args, nowUTC(), req.ID)
+		if req.ExpectedVersion > 0 {
+			query += ` AND version = ?`
+			args = append(args, req.ExpectedVersion)
+		}
+
+		res, err := r.data.Conn(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("update product %s: %w", req.ID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			// Either the product is gone or its version moved on.
+			if _, err := findProduct(ctx, r.data, req.ID, false); err != nil {
+				return err
+			}
+			return biz.ErrVersionConflict
+		}
+
+		if productEntity, err = findProduct(ctx, r.data, req.ID, false); err != nil {
+			return err
+		}
+		if replaceAttributes {
+			if _, err := r.data.Conn(ctx).ExecContext(ctx,
+				`DELETE FROM product_attributes WHERE product_id = ?`, req.ID); err != nil {
+				return fmt.Errorf("replace attributes %s: %w", req.ID, err)
+			}
+			err = insertAttributes(ctx, r.data, productEntity, req.Attributes)
+		} else {
+			err = loadAttributes(ctx, r.data, productEntity)
+		}
+		if err != nil {
+			return err
+		}
+		return recordProductEvent(ctx, r.data, outbox.TypeProductUpdated, productEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	r.log.Infof("Product updated: %s", productEntity.ID)
+	r.log.Infof("Product updated: %s (version %d)", productEntity.ID, productEntity.Version)
 
 	// Convert entity to DTO
 	return productEntity.ToDTO(), nil
 }
 
+// DeleteProduct soft-deletes the product. Its stock levels are kept so that
+// RestoreProduct brings it back unchanged.
 func (r *inventoryRepo) DeleteProduct(ctx context.Context, id string) error {
-	// TODO: Delete entity from database
-	// r.data.db.Delete(&entity.Product{}, \"id = ?\", id)
-	
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var variants int
+		if err := r.data.Conn(ctx).QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM products WHERE parent_id = ? AND `+notDeleted, id,
+		).Scan(&variants); err != nil {
+			return fmt.Errorf("count variants %s: %w", id, err)
+		}
+		if variants > 0 {
+			return biz.ErrProductHasVariants
+		}
+
+		productEntity, err := findProduct(ctx, r.data, id, true)
+		if err != nil {
+			return err
+		}
+		now := nowUTC()
+		if _, err := r.data.Conn(ctx).ExecContext(ctx,
+			`UPDATE products SET deleted_at = ?, version = version + 1, updated_at = ? WHERE id = ? AND `+notDeleted,
+			now, now, id,
+		); err != nil {
+			return fmt.Errorf("delete product %s: %w", id, err)
+		}
+		productEntity.DeletedAt, productEntity.UpdatedAt = &now, now
+		productEntity.Version++
+		return recordProductEvent(ctx, r.data, outbox.TypeProductDeleted, productEntity)
+	})
+	if err != nil {
+		return err
+	}
+
 	r.log.Infof("Product deleted: %s", id)
 	return nil
 }
 
+// RestoreProduct clears deleted_at. Restoring a product that is not deleted
+// leaves it untouched.
+func (r *inventoryRepo) RestoreProduct(ctx context.Context, id string) (*dto.ProductDTO, error) {
+	var productEntity *entity.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		res, err := r.data.Conn(ctx).ExecContext(ctx,
+			`UPDATE products SET deleted_at = NULL, version = version + 1, updated_at = ? WHERE id = ? AND deleted_at IS NOT NULL`,
+			nowUTC(), id,
+		)
+		if sqldb.IsDuplicateKey(err) {
+			// A live product took over the SKU after this one was deleted.
+			return biz.ErrSKUAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("restore product %s: %w", id, err)
+		}
+		restored, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if productEntity, err = findProduct(ctx, r.data, id, false); err != nil {
+			return err
+		}
+		if restored > 0 {
+			if err := recordProductEvent(ctx, r.data, outbox.TypeProductUpdated, productEntity); err != nil {
+				return err
+			}
+		}
+		return r.loadDetails(ctx, productEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Product restored: %s", id)
+
+	// Convert entity to DTO
+	return productEntity.ToDTO(), nil
+}
+
+// PurgeDeletedProducts hard-deletes products soft-deleted before the cutoff,
+// together with their stock levels and attributes. Movements and reservations are kept for
+// reporting.
+func (r *inventoryRepo) PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		for _, table := range []string{"stock_levels", "product_attributes"} {
+			if _, err := r.data.Conn(ctx).ExecContext(ctx,
+				`DELETE FROM `+table+` WHERE product_id IN (SELECT id FROM products WHERE deleted_at < ?)`,
+				before,
+			); err != nil {
+				return fmt.Errorf("purge %s: %w", table, err)
+			}
+		}
+		res, err := r.data.Conn(ctx).ExecContext(ctx, `DELETE FROM products WHERE deleted_at < ?`, before)
+		if err != nil {
+			return fmt.Errorf("purge products: %w", err)
+		}
+		purged, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		r.log.Infof("Purged %d deleted products", purged)
+	}
+	return purged, nil
+}
+
+// UpdateStock applies the stock change as a single conditional UPDATE so
+// concurrent requests cannot lose updates or drive stock below zero.
 func (r *inventoryRepo) UpdateStock(ctx context.Context, req *dto.UpdateStockDTO) (*dto.ProductDTO, error) {
-	// TODO: Get entity from database
-	// var productEntity entity.Product
-	// r.data.db.First(&productEntity, \"id = ?\", req.ID)
-	
-	// Mock current stock
-	currentStock := int32(100)
-	
-	var newStock int32
+	var productEntity *entity.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if productEntity, err = r.applyStock(ctx, req); err != nil {
+			return err
+		}
+		return loadStockLevels(ctx, r.data, productEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Stock updated for product %s at %s: %s %d -> %d", req.ID, req.LocationID, req.Operation, req.Quantity, productEntity.Stock)
+
+	// Convert entity to DTO
+	return productEntity.ToDTO(), nil
+}
+
+// BatchUpdateStock applies the items in order within one transaction; the
+// first failing item rolls back the whole batch.
+func (r *inventoryRepo) BatchUpdateStock(ctx context.Context, items []*dto.UpdateStockDTO) ([]*dto.ProductDTO, error) {
+	dtos := make([]*dto.ProductDTO, len(items))
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			productEntity, err := r.applyStock(ctx, item)
+			if err != nil {
+				return biz.BatchItemError(i, item.ID, err)
+			}
+			dtos[i] = productEntity.ToDTO()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Stock batch applied: %d items", len(items))
+	return dtos, nil
+}
+
+// applyStock performs one stock change on the product total and the
+// location's level, and records its movement. It must run inside InTx.
+func (r *inventoryRepo) applyStock(ctx context.Context, req *dto.UpdateStockDTO) (*entity.Product, error) {
+	var (
+		query string
+		args  []any
+		delta int32
+	)
 	switch req.Operation {
 	case "add":
-		newStock = currentStock + req.Quantity
+		query = `UPDATE products SET stock = stock + ?, version = version + 1, updated_at = ? WHERE id = ? AND ` + notDeleted
+		args = []any{req.Quantity, nowUTC(), req.ID}
+		delta = req.Quantity
 	case "subtract":
-		newStock = currentStock - req.Quantity
-		if newStock < 0 {
-			return nil, fmt.Errorf("insufficient stock")
-		}
+		// Only the available quantity may go: units held by active
+		// reservations stay on hand until they are committed or released.
+		now := nowUTC()
+		query = `UPDATE products SET stock = stock - ?, version = version + 1, updated_at = ?
+			WHERE id = ? AND stock - ` + reservedColumn + ` >= ? AND ` + notDeleted
+		args = []any{req.Quantity, now, req.ID, now, req.Quantity}
+		delta = -req.Quantity
 	default:
 		return nil, fmt.Errorf("invalid operation: %s", req.Operation)
 	}
+	if req.ExpectedVersion > 0 {
+		query += ` AND version = ?`
+		args = append(args, req.ExpectedVersion)
+	}
 
-	// Update entity
-	productEntity := &entity.Product{
-		ID:        req.ID,
-		Stock:     newStock,
-		UpdatedAt: time.Now(),
+	res, err := r.data.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("update stock %s: %w", req.ID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := findProduct(ctx, r.data, req.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		if req.ExpectedVersion > 0 && productEntity.Version != req.ExpectedVersion {
+			return nil, biz.ErrVersionConflict
+		}
+		return nil, biz.ErrInsufficientStock
+	}
+	if err := adjustStockLevel(ctx, r.data, req.ID, req.LocationID, delta); err != nil {
+		return nil, err
 	}
 
-	// TODO: Save entity to database
-	// r.data.db.Save(productEntity)
+	if err := recordMovement(ctx, r.data, &entity.StockMovement{
+		ProductID:   req.ID,
+		Delta:       delta,
+		Reason:      req.Reason,
+		ReferenceID: req.ReferenceID,
+		Actor:       req.Actor,
+		LocationID:  req.LocationID,
+	}); err != nil {
+		return nil, err
+	}
+	return productEntity, nil
+}
+
+// TransferStock moves stock between two locations. The product total is
+// unchanged but its version is bumped like any other stock change.
+func (r *inventoryRepo) TransferStock(ctx context.Context, req *dto.TransferStockDTO) (*dto.ProductDTO, error) {
+	var productEntity *entity.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		res, err := r.data.Conn(ctx).ExecContext(ctx,
+			`UPDATE products SET version = version + 1, updated_at = ? WHERE id = ? AND `+notDeleted,
+			nowUTC(), req.ProductID,
+		)
+		if err != nil {
+			return fmt.Errorf("transfer stock %s: %w", req.ProductID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return biz.ErrProductNotFound
+		}
+
+		if err := adjustStockLevel(ctx, r.data, req.ProductID, req.FromLocationID, -req.Quantity); err != nil {
+			return err
+		}
+		if err := adjustStockLevel(ctx, r.data, req.ProductID, req.ToLocationID, req.Quantity); err != nil {
+			return err
+		}
+
+		for _, m := range []*entity.StockMovement{
+			{Delta: -req.Quantity, Reason: dto.MovementReasonTransferOut, LocationID: req.FromLocationID},
+			{Delta: req.Quantity, Reason: dto.MovementReasonTransferIn, LocationID: req.ToLocationID},
+		} {
+			m.ProductID, m.ReferenceID, m.Actor = req.ProductID, req.ReferenceID, req.Actor
+			if err := recordMovement(ctx, r.data, m); err != nil {
+				return err
+			}
+		}
+
+		if productEntity, err = findProduct(ctx, r.data, req.ProductID, false); err != nil {
+			return err
+		}
+		return loadStockLevels(ctx, r.data, productEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	r.log.Infof("Stock updated for product %s: %d -> %d", req.ID, currentStock, newStock)
+	r.log.Infof("Stock transferred for product %s: %d from %s to %s", req.ProductID, req.Quantity, req.FromLocationID, req.ToLocationID)
 
 	// Convert entity to DTO
 	return productEntity.ToDTO(), nil
@@ -0,0 +1,167 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestProductLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+
+	created, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+		Name:       "Mug",
+		SKU:        "MUG-1",
+		Price:      dto.Money{CurrencyCode: dto.DefaultCurrency, Units: 120, Nanos: 500000000},
+		Stock:      7,
+		LocationID: dto.DefaultLocationID,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	got, err := repo.GetProduct(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if got.SKU != "MUG-1" || got.Stock != 7 || got.Price.Units != 120 || got.Price.Nanos != 500000000 || got.Version != 1 {
+		t.Fatalf("GetProduct returned %+v", got)
+	}
+
+	updated, err := repo.UpdateProduct(ctx, &dto.UpdateProductDTO{
+		ID:              created.ID,
+		Name:            "Large mug",
+		Description:     "not written",
+		ExpectedVersion: 1,
+		UpdateMask:      []string{dto.ProductFieldName},
+	})
+	if err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+	if updated.Name != "Large mug" || updated.Description != "" || updated.Version != 2 {
+		t.Fatalf("UpdateProduct returned %+v", updated)
+	}
+	if _, err := repo.UpdateProduct(ctx, &dto.UpdateProductDTO{
+		ID:              created.ID,
+		Name:            "Stale",
+		ExpectedVersion: 1,
+		UpdateMask:      []string{dto.ProductFieldName},
+	}); !errors.Is(err, biz.ErrVersionConflict) {
+		t.Fatalf("UpdateProduct with a stale version: got %v, want ErrVersionConflict", err)
+	}
+
+	if err := repo.DeleteProduct(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	if _, err := repo.GetProduct(ctx, created.ID); !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("GetProduct after delete: got %v, want ErrProductNotFound", err)
+	}
+
+	restored, err := repo.RestoreProduct(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("RestoreProduct: %v", err)
+	}
+	if restored.Stock != 7 || restored.DeletedAt != nil {
+		t.Fatalf("RestoreProduct returned %+v", restored)
+	}
+}
+
+func TestListProductsPages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+	for i := 0; i < 5; i++ {
+		if _, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+			Name:       fmt.Sprintf("Product %d", i),
+			SKU:        fmt.Sprintf("SKU-%d", i),
+			LocationID: dto.DefaultLocationID,
+		}); err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		for page, want := range []int{2, 2, 1} {
+			products, pageInfo, err := repo.ListProducts(ctx, &dto.ListProductsQuery{
+				Page: int32(page + 1), PageSize: 2, OrderBy: dto.ProductSortSKU,
+			})
+			if err != nil {
+				t.Fatalf("page %d: %v", page+1, err)
+			}
+			if len(products) != want || pageInfo.Total != 5 {
+				t.Fatalf("page %d: got %d products of %d, want %d of 5", page+1, len(products), pageInfo.Total, want)
+			}
+			if sku := fmt.Sprintf("SKU-%d", page*2); products[0].SKU != sku {
+				t.Fatalf("page %d starts at %s, want %s", page+1, products[0].SKU, sku)
+			}
+		}
+	})
+
+	t.Run("token", func(t *testing.T) {
+		query := &dto.ListProductsQuery{Page: 1, PageSize: 2, OrderBy: dto.ProductSortSKU, SkipTotal: true}
+		var skus []string
+		for {
+			products, pageInfo, err := repo.ListProducts(ctx, query)
+			if err != nil {
+				t.Fatalf("ListProducts: %v", err)
+			}
+			if pageInfo.Total != 0 {
+				t.Fatalf("SkipTotal counted %d products", pageInfo.Total)
+			}
+			for _, p := range products {
+				skus = append(skus, p.SKU)
+			}
+			if pageInfo.NextPageToken == "" {
+				break
+			}
+			query.PageToken = pageInfo.NextPageToken
+		}
+		if fmt.Sprint(skus) != "[SKU-0 SKU-1 SKU-2 SKU-3 SKU-4]" {
+			t.Fatalf("listed %v", skus)
+		}
+	})
+}
+
+func TestListProductsTokenKeepsPointerFilters(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+	for i := 0; i < 5; i++ {
+		if _, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+			Name:       fmt.Sprintf("Product %d", i),
+			SKU:        fmt.Sprintf("SKU-%d", i),
+			Price:      dto.Money{CurrencyCode: dto.DefaultCurrency, Units: int64(10 * (i + 1))},
+			Stock:      int32(i % 2 * 3),
+			LocationID: dto.DefaultLocationID,
+		}); err != nil {
+			t.Fatalf("CreateProduct: %v", err)
+		}
+	}
+
+	// Every request decodes its filters into fresh pointers, as the service does.
+	list := func(token string) ([]*dto.ProductDTO, string) {
+		minPrice, inStock := 20.0, true
+		products, pageInfo, err := repo.ListProducts(ctx, &dto.ListProductsQuery{
+			Page: 1, PageSize: 1, OrderBy: dto.ProductSortSKU,
+			MinPrice: &minPrice, InStock: &inStock, PageToken: token,
+		})
+		if err != nil {
+			t.Fatalf("ListProducts: %v", err)
+		}
+		return products, pageInfo.NextPageToken
+	}
+
+	first, token := list("")
+	if token == "" {
+		t.Fatal("first page has no next page token")
+	}
+	second, _ := list(token)
+	if len(first) != 1 || len(second) != 1 || first[0].SKU != "SKU-1" || second[0].SKU != "SKU-3" {
+		t.Fatalf("pages %v, %v; want SKU-1 then SKU-3", first, second)
+	}
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+
+	"github.com/reverny/kratos-mono/pkg/sqldb"
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+const locationColumns = `id, code, name, created_at, updated_at`
+
+type locationRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewLocationRepo .
+func NewLocationRepo(data *Data, logger log.Logger) biz.LocationRepo {
+	return &locationRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *locationRepo) CreateLocation(ctx context.Context, req *dto.CreateLocationDTO) (*dto.LocationDTO, error) {
+	now := nowUTC()
+	locationEntity := &entity.Location{
+		ID:        uuid.New().String(),
+		Code:      req.Code,
+		Name:      req.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if _, err := r.data.Conn(ctx).ExecContext(ctx,
+		`INSERT INTO locations (`+locationColumns+`) VALUES (?, ?, ?, ?, ?)`,
+		locationEntity.ID,
+		locationEntity.Code,
+		locationEntity.Name,
+		locationEntity.CreatedAt,
+		locationEntity.UpdatedAt,
+	); err != nil {
+		if sqldb.IsDuplicateKey(err) {
+			return nil, biz.ErrLocationCodeExists
+		}
+		return nil, fmt.Errorf("create location: %w", err)
+	}
+
+	r.log.Infof("Location created: %s (%s)", locationEntity.ID, locationEntity.Code)
+	return locationEntity.ToDTO(), nil
+}
+
+func (r *locationRepo) ListLocations(ctx context.Context) ([]*dto.LocationDTO, error) {
+	rows, err := r.data.Conn(ctx).QueryContext(ctx,
+		`SELECT `+locationColumns+` FROM locations ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	defer rows.Close()
+
+	var dtos []*dto.LocationDTO
+	for rows.Next() {
+		var e entity.Location
+		if err := rows.Scan(&e.ID, &e.Code, &e.Name, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan location: %w", err)
+		}
+		dtos = append(dtos, e.ToDTO())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	return dtos, nil
+}
+
+// checkLocation returns biz.ErrLocationNotFound unless the location exists.
+func checkLocation(ctx context.Context, d *Data, id string) error {
+	var one int
+	err := d.Conn(ctx).QueryRowContext(ctx, `SELECT 1 FROM locations WHERE id = ?`, id).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return biz.ErrLocationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get location %s: %w", id, err)
+	}
+	return nil
+}
+
+// adjustStockLevel changes the quantity of a product held at a location,
+// refusing to take it below zero. It must run inside InTx after the
+// products row has been updated, which serialises changes per product.
+func adjustStockLevel(ctx context.Context, d *Data, productID, locationID string, delta int32) error {
+	now := nowUTC()
+	if delta < 0 {
+		res, err := d.Conn(ctx).ExecContext(ctx,
+			`UPDATE stock_levels SET quantity = quantity + ?, updated_at = ? WHERE product_id = ? AND location_id = ? AND quantity >= ?`,
+			delta, now, productID, locationID, -delta,
+		)
+		if err != nil {
+			return fmt.Errorf("update stock level %s/%s: %w", productID, locationID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			if err := checkLocation(ctx, d, locationID); err != nil {
+				return err
+			}
+			return biz.ErrInsufficientStock
+		}
+		return nil
+	}
+
+	res, err := d.Conn(ctx).ExecContext(ctx,
+		`UPDATE stock_levels SET quantity = quantity + ?, updated_at = ? WHERE product_id = ? AND location_id = ?`,
+		delta, now, productID, locationID,
+	)
+	if err != nil {
+		return fmt.Errorf("update stock level %s/%s: %w", productID, locationID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n > 0 {
+		return nil
+	}
+
+	// First stock of this product at the location.
+	if err := checkLocation(ctx, d, locationID); err != nil {
+		return err
+	}
+	if _, err := d.Conn(ctx).ExecContext(ctx,
+		`INSERT INTO stock_levels (product_id, location_id, quantity, updated_at) VALUES (?, ?, ?, ?)`,
+		productID, locationID, delta, now,
+	); err != nil {
+		return fmt.Errorf("create stock level %s/%s: %w", productID, locationID, err)
+	}
+	return nil
+}
+
+// loadStockLevels attaches the per-location stock of e.
+func loadStockLevels(ctx context.Context, d *Data, e *entity.Product) error {
+	rows, err := d.Conn(ctx).QueryContext(ctx,
+		`SELECT product_id, location_id, quantity, updated_at FROM stock_levels WHERE product_id = ? ORDER BY location_id`,
+		e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("list stock levels %s: %w", e.ID, err)
+	}
+	defer rows.Close()
+
+	e.StockLevels = []*entity.StockLevel{}
+	for rows.Next() {
+		var level entity.StockLevel
+		if err := rows.Scan(&level.ProductID, &level.LocationID, &level.Quantity, &level.UpdatedAt); err != nil {
+			return fmt.Errorf("scan stock level: %w", err)
+		}
+		e.StockLevels = append(e.StockLevels, &level)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,15 @@
+-- Products held by the inventory service.
+CREATE TABLE products (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    price DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    stock INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_products_sku ON products (sku);
+
+CREATE INDEX idx_products_created_at ON products (created_at);
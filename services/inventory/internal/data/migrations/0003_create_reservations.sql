@@ -0,0 +1,14 @@
+-- Stock held for checkout. Active rows past expires_at no longer count
+-- against available stock.
+CREATE TABLE reservations (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    product_id VARCHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    reference_id VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_reservations_product_status ON reservations (product_id, status, expires_at);
@@ -0,0 +1,13 @@
+-- Append-only stock ledger. Ids are UUIDv7 so (created_at, id) is chronological.
+CREATE TABLE stock_movements (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    product_id VARCHAR(36) NOT NULL,
+    delta INT NOT NULL,
+    stock_after INT NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    reference_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_stock_movements_product_created ON stock_movements (product_id, created_at, id);
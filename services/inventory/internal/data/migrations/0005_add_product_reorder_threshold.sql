@@ -0,0 +1,2 @@
+-- Reorder point per product; 0 disables low-stock alerts.
+ALTER TABLE products ADD COLUMN reorder_threshold INT NOT NULL DEFAULT 0;
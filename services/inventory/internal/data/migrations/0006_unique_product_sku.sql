@@ -0,0 +1,4 @@
+-- SKUs identify products for scanners and must be unique. The older
+-- non-unique idx_products_sku is left in place: DROP INDEX syntax differs
+-- between MySQL and SQLite.
+CREATE UNIQUE INDEX uq_products_sku ON products (sku);
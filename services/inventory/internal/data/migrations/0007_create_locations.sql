@@ -0,0 +1,28 @@
+-- Warehouses and the stock each holds per product. products.stock remains
+-- the total across locations and is kept in step by every stock change.
+CREATE TABLE locations (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX uq_locations_code ON locations (code);
+
+CREATE TABLE stock_levels (
+    product_id VARCHAR(36) NOT NULL,
+    location_id VARCHAR(36) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (product_id, location_id)
+);
+
+-- Existing stock moves to the default location.
+INSERT INTO locations (id, code, name, created_at, updated_at)
+VALUES ('default', 'DEFAULT', 'Default', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+
+INSERT INTO stock_levels (product_id, location_id, quantity, updated_at)
+SELECT id, 'default', stock, updated_at FROM products WHERE stock <> 0;
+
+ALTER TABLE stock_movements ADD COLUMN location_id VARCHAR(36) NOT NULL DEFAULT 'default';
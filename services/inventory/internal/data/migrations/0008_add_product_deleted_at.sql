@@ -0,0 +1,5 @@
+-- Soft delete: a non-NULL deleted_at hides the product until it is restored
+-- or purged after the configured retention.
+ALTER TABLE products ADD COLUMN deleted_at DATETIME NULL;
+
+CREATE INDEX idx_products_deleted_at ON products (deleted_at);
@@ -0,0 +1,12 @@
+-- Prices as exact units + nanos with a currency. The DECIMAL price column is
+-- kept in step for the price filters and ordering of ListProducts.
+ALTER TABLE products ADD COLUMN price_units BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE products ADD COLUMN price_nanos INT NOT NULL DEFAULT 0;
+
+ALTER TABLE products ADD COLUMN currency_code VARCHAR(3) NOT NULL DEFAULT 'THB';
+
+-- Existing prices have two decimal places and are never negative.
+UPDATE products SET
+    price_units = (ROUND(price * 100) - ROUND(price * 100) % 100) / 100,
+    price_nanos = (ROUND(price * 100) % 100) * 10000000;
@@ -0,0 +1,15 @@
+-- Variants are products of their own, with their own SKU, price and stock,
+-- linked to a parent product. Attributes hold the typed values, such as size
+-- or color, that tell variants apart.
+ALTER TABLE products ADD COLUMN parent_id VARCHAR(36) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_products_parent_id ON products (parent_id);
+
+CREATE TABLE product_attributes (
+    product_id VARCHAR(36) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    value_type VARCHAR(16) NOT NULL,
+    value TEXT NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, name)
+);
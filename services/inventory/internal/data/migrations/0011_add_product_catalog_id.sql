@@ -0,0 +1,5 @@
+-- The catalog (product service) owns product identity. catalog_id is the id
+-- of the catalog entry with the same SKU; 0 marks rows not linked yet.
+ALTER TABLE products ADD COLUMN catalog_id BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_catalog_id ON products (catalog_id);
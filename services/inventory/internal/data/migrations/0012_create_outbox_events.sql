@@ -0,0 +1,12 @@
+-- Domain events recorded in the same transaction as the change they
+-- describe. The outbox relay publishes them in id order and deletes them.
+CREATE TABLE outbox_events (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    source VARCHAR(32) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL
+);
@@ -0,0 +1,13 @@
+-- A SKU only has to be unique among live products, so that the SKU of a
+-- soft-deleted product can be used again. live_sku is NULL for deleted rows
+-- and NULLs never collide in a unique index. Lookups by SKU only ever look
+-- for live products and use the same index.
+ALTER TABLE products ADD COLUMN live_sku VARCHAR(64) GENERATED ALWAYS AS (CASE WHEN deleted_at IS NULL THEN sku END) VIRTUAL;
+
+CREATE UNIQUE INDEX uq_products_live_sku ON products (live_sku);
+
+DROP INDEX uq_products_sku ON products;
+
+-- Redundant since 0006 made SKUs unique, and unused now that lookups go
+-- through live_sku.
+DROP INDEX idx_products_sku ON products;
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+const movementColumns = `id, product_id, delta, stock_after, reason, reference_id, actor, location_id, created_at`
+
+func scanMovement(row rowScanner) (*entity.StockMovement, error) {
+	var e entity.StockMovement
+	if err := row.Scan(
+		&e.ID,
+		&e.ProductID,
+		&e.Delta,
+		&e.StockAfter,
+		&e.Reason,
+		&e.ReferenceID,
+		&e.Actor,
+		&e.LocationID,
+		&e.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// recordMovement appends e to the stock ledger. It must run inside the
+// InTx that changed the stock so the ledger and products never disagree;
+// ID, StockAfter and CreatedAt are filled in here.
+func recordMovement(ctx context.Context, d *Data, e *entity.StockMovement) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("record stock movement: %w", err)
+	}
+	e.ID = id.String()
+	e.CreatedAt = nowUTC()
+
+	if err := d.Conn(ctx).QueryRowContext(ctx,
+		`SELECT stock FROM products WHERE id = ?`, e.ProductID,
+	).Scan(&e.StockAfter); err != nil {
+		return fmt.Errorf("record stock movement: %w", err)
+	}
+
+	if _, err := d.Conn(ctx).ExecContext(ctx,
+		`INSERT INTO stock_movements (`+movementColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID,
+		e.ProductID,
+		e.Delta,
+		e.StockAfter,
+		e.Reason,
+		e.ReferenceID,
+		e.Actor,
+		e.LocationID,
+		e.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("record stock movement: %w", err)
+	}
+	return recordStockChanged(ctx, d, e)
+}
+
+func (r *inventoryRepo) ListStockMovements(ctx context.Context, query *dto.ListStockMovementsQuery) ([]*dto.StockMovementDTO, int32, error) {
+	where := ` WHERE product_id = ?`
+	args := []any{query.ProductID}
+	if !query.StartTime.IsZero() {
+		where += ` AND created_at >= ?`
+		args = append(args, query.StartTime.UTC())
+	}
+	if !query.EndTime.IsZero() {
+		where += ` AND created_at < ?`
+		args = append(args, query.EndTime.UTC())
+	}
+
+	var total int32
+	if err := r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM stock_movements`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count stock movements: %w", err)
+	}
+	if total == 0 {
+		// The ledger outlives the product, so only an id that never had a
+		// product or a movement is unknown; deleted and purged ones are not.
+		var known bool
+		if err := r.data.Conn(ctx).QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM products WHERE id = ?) OR EXISTS (SELECT 1 FROM stock_movements WHERE product_id = ?)`,
+			query.ProductID, query.ProductID,
+		).Scan(&known); err != nil {
+			return nil, 0, fmt.Errorf("get product %s: %w", query.ProductID, err)
+		}
+		if !known {
+			return nil, 0, biz.ErrProductNotFound
+		}
+	}
+
+	rows, err := r.data.Conn(ctx).QueryContext(ctx,
+		`SELECT `+movementColumns+` FROM stock_movements`+where+` ORDER BY created_at, id LIMIT ? OFFSET ?`,
+		append(args, query.PageSize, (query.Page-1)*query.PageSize)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var dtos []*dto.StockMovementDTO
+	for rows.Next() {
+		movementEntity, err := scanMovement(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan stock movement: %w", err)
+		}
+		dtos = append(dtos, movementEntity.ToDTO())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list stock movements: %w", err)
+	}
+
+	return dtos, total, nil
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestStockMovementsOutliveTheProduct(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+	p, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{Name: "Mug", SKU: "MUG-1", Stock: 5, LocationID: dto.DefaultLocationID})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := repo.UpdateStock(ctx, &dto.UpdateStockDTO{
+		ID: p.ID, Operation: "subtract", Quantity: 2, Reason: dto.MovementReasonAdjustment, LocationID: dto.DefaultLocationID,
+	}); err != nil {
+		t.Fatalf("UpdateStock: %v", err)
+	}
+
+	list := func(id string) ([]*dto.StockMovementDTO, error) {
+		movements, _, err := repo.ListStockMovements(ctx, &dto.ListStockMovementsQuery{ProductID: id, Page: 1, PageSize: 10})
+		return movements, err
+	}
+	if movements, err := list(p.ID); err != nil || len(movements) != 2 {
+		t.Fatalf("live product: %d movements, %v; want 2", len(movements), err)
+	}
+
+	if err := repo.DeleteProduct(ctx, p.ID); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	if movements, err := list(p.ID); err != nil || len(movements) != 2 {
+		t.Fatalf("deleted product: %d movements, %v; want 2", len(movements), err)
+	}
+
+	if n, err := repo.PurgeDeletedProducts(ctx, time.Now().Add(time.Hour)); err != nil || n != 1 {
+		t.Fatalf("PurgeDeletedProducts purged %d: %v", n, err)
+	}
+	if movements, err := list(p.ID); err != nil || len(movements) != 2 {
+		t.Fatalf("purged product: %d movements, %v; want 2", len(movements), err)
+	}
+
+	if _, err := list("no-such-product"); !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("unknown product: got %v, want ErrProductNotFound", err)
+	}
+}
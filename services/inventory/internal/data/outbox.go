@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/pkg/money"
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/outbox/outboxkafka"
+	"github.com/reverny/kratos-mono/pkg/outbox/outboxnats"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
+)
+
+// eventSource names this service in the events it records.
+const eventSource = "inventory"
+
+// Supported values for conf.Data.Outbox.Publisher.
+const (
+	publisherChannel = "channel"
+	publisherNATS    = "nats"
+	publisherKafka   = "kafka"
+)
+
+// NewOutboxRelay builds the relay that delivers recorded events to the
+// publisher chosen by c.Outbox. The channel publisher hands them to
+// subscribers of events and holds them back while there are none.
+func NewOutboxRelay(c *conf.Data, d *Data, events *outbox.ChannelPublisher, logger log.Logger) (*outbox.Relay, func(), error) {
+	oc := c.GetOutbox()
+
+	var (
+		publisher outbox.Publisher
+		cleanup   = func() {}
+	)
+	switch oc.GetPublisher() {
+	case "", publisherChannel:
+		publisher = events
+	case publisherNATS:
+		p, closeConn, err := outboxnats.Connect(oc.GetNatsUrl(), oc.GetNatsSubjectPrefix())
+		if err != nil {
+			return nil, nil, err
+		}
+		publisher, cleanup = p, closeConn
+	case publisherKafka:
+		w := outboxkafka.NewWriter(oc.GetKafkaBrokers(), oc.GetKafkaTopic())
+		publisher = outboxkafka.NewPublisher(w)
+		cleanup = func() {
+			if err := w.Close(); err != nil {
+				log.NewHelper(logger).Error(err)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported outbox publisher: %q", oc.GetPublisher())
+	}
+
+	relay := outbox.NewRelay(outbox.NewSQLStore(d.SQL()), publisher,
+		oc.GetRelayInterval().AsDuration(), int(oc.GetBatchSize()), logger)
+	return relay, cleanup, nil
+}
+
+// productEvent is the payload of the Product* events.
+type productEvent struct {
+	ID               string     `json:"id"`
+	CatalogID        int64      `json:"catalog_id,omitempty"`
+	SKU              string     `json:"sku"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	Price            string     `json:"price"` // decimal, e.g. "19.99"
+	CurrencyCode     string     `json:"currency_code"`
+	Stock            int32      `json:"stock"`
+	ReorderThreshold int32      `json:"reorder_threshold"`
+	ParentID         string     `json:"parent_id,omitempty"`
+	Version          int64      `json:"version"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+}
+
+// stockChangedEvent is the payload of StockChanged, one per stock movement.
+type stockChangedEvent struct {
+	ProductID   string `json:"product_id"`
+	MovementID  string `json:"movement_id"`
+	LocationID  string `json:"location_id"`
+	Delta       int32  `json:"delta"`
+	Stock       int32  `json:"stock"` // total across locations after the change
+	Reason      string `json:"reason"`
+	ReferenceID string `json:"reference_id,omitempty"`
+}
+
+// recordProductEvent appends a Product* event for e to the outbox in the
+// transaction bound to ctx.
+func recordProductEvent(ctx context.Context, d *Data, eventType string, e *entity.Product) error {
+	event, err := outbox.NewEvent(eventSource, eventType, e.ID, &productEvent{
+		ID:               e.ID,
+		CatalogID:        e.CatalogID,
+		SKU:              e.SKU,
+		Name:             e.Name,
+		Description:      e.Description,
+		Price:            money.Format(e.PriceUnits, e.PriceNanos),
+		CurrencyCode:     e.CurrencyCode,
+		Stock:            e.Stock,
+		ReorderThreshold: e.ReorderThreshold,
+		ParentID:         e.ParentID,
+		Version:          e.Version,
+		DeletedAt:        e.DeletedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return outbox.Append(ctx, d.Conn(ctx), event)
+}
+
+// recordStockChanged appends the StockChanged event for m to the outbox in
+// the transaction bound to ctx.
+func recordStockChanged(ctx context.Context, d *Data, m *entity.StockMovement) error {
+	event, err := outbox.NewEvent(eventSource, outbox.TypeStockChanged, m.ProductID, &stockChangedEvent{
+		ProductID:   m.ProductID,
+		MovementID:  m.ID,
+		LocationID:  m.LocationID,
+		Delta:       m.Delta,
+		Stock:       m.StockAfter,
+		Reason:      m.Reason,
+		ReferenceID: m.ReferenceID,
+	})
+	if err != nil {
+		return err
+	}
+	return outbox.Append(ctx, d.Conn(ctx), event)
+}
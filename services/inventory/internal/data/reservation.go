@@ -0,0 +1,203 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+const reservationColumns = `id, product_id, quantity, reference_id, status, expires_at, created_at, updated_at`
+
+type reservationRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewReservationRepo .
+func NewReservationRepo(data *Data, logger log.Logger) biz.ReservationRepo {
+	return &reservationRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func scanReservation(row rowScanner) (*entity.Reservation, error) {
+	var e entity.Reservation
+	if err := row.Scan(
+		&e.ID,
+		&e.ProductID,
+		&e.Quantity,
+		&e.ReferenceID,
+		&e.Status,
+		&e.ExpiresAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *reservationRepo) findReservation(ctx context.Context, id string, lock bool) (*entity.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = ?`
+	if lock {
+		query += r.data.ForUpdate()
+	}
+	reservationEntity, err := scanReservation(r.data.Conn(ctx).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get reservation %s: %w", id, err)
+	}
+	return reservationEntity, nil
+}
+
+func (r *reservationRepo) setStatus(ctx context.Context, e *entity.Reservation, status string) error {
+	e.Status = status
+	e.UpdatedAt = nowUTC()
+	if _, err := r.data.Conn(ctx).ExecContext(ctx,
+		`UPDATE reservations SET status = ?, updated_at = ? WHERE id = ?`,
+		e.Status, e.UpdatedAt, e.ID,
+	); err != nil {
+		return fmt.Errorf("update reservation %s: %w", e.ID, err)
+	}
+	return nil
+}
+
+func (r *reservationRepo) ReserveStock(ctx context.Context, req *dto.ReserveStockDTO) (*dto.ReservationDTO, error) {
+	now := nowUTC()
+	reservationEntity := &entity.Reservation{
+		ID:          uuid.New().String(),
+		ProductID:   req.ProductID,
+		Quantity:    req.Quantity,
+		ReferenceID: req.ReferenceID,
+		Status:      dto.ReservationActive,
+		ExpiresAt:   now.Add(req.TTL),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		// Lock the product so concurrent reservations see each other.
+		productEntity, err := findProduct(ctx, r.data, req.ProductID, true)
+		if err != nil {
+			return err
+		}
+		if productEntity.Stock-productEntity.Reserved < req.Quantity {
+			return biz.ErrInsufficientStock
+		}
+
+		if _, err := r.data.Conn(ctx).ExecContext(ctx,
+			`INSERT INTO reservations (`+reservationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			reservationEntity.ID,
+			reservationEntity.ProductID,
+			reservationEntity.Quantity,
+			reservationEntity.ReferenceID,
+			reservationEntity.Status,
+			reservationEntity.ExpiresAt,
+			reservationEntity.CreatedAt,
+			reservationEntity.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("create reservation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Reserved %d of product %s: %s", req.Quantity, req.ProductID, reservationEntity.ID)
+	return reservationEntity.ToDTO(), nil
+}
+
+func (r *reservationRepo) CommitReservation(ctx context.Context, req *dto.CommitReservationDTO) (*dto.ReservationDTO, error) {
+	id := req.ID
+	var reservationEntity *entity.Reservation
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		reservationEntity, err = r.findReservation(ctx, id, true)
+		if err != nil {
+			return err
+		}
+		if !reservationEntity.Active(nowUTC()) {
+			return biz.ErrReservationNotActive
+		}
+
+		// The reserved quantity now leaves on-hand stock for good.
+		res, err := r.data.Conn(ctx).ExecContext(ctx,
+			`UPDATE products SET stock = stock - ?, version = version + 1, updated_at = ? WHERE id = ? AND stock >= ? AND `+notDeleted,
+			reservationEntity.Quantity, nowUTC(), reservationEntity.ProductID, reservationEntity.Quantity,
+		)
+		if err != nil {
+			return fmt.Errorf("commit reservation %s: %w", id, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			if _, err := findProduct(ctx, r.data, reservationEntity.ProductID, false); err != nil {
+				return err
+			}
+			return biz.ErrInsufficientStock
+		}
+		if err := adjustStockLevel(ctx, r.data, reservationEntity.ProductID, req.LocationID, -reservationEntity.Quantity); err != nil {
+			return err
+		}
+
+		referenceID := reservationEntity.ReferenceID
+		if referenceID == "" {
+			referenceID = reservationEntity.ID
+		}
+		if err := recordMovement(ctx, r.data, &entity.StockMovement{
+			ProductID:   reservationEntity.ProductID,
+			Delta:       -reservationEntity.Quantity,
+			Reason:      dto.MovementReasonReservationCommit,
+			ReferenceID: referenceID,
+			Actor:       req.Actor,
+			LocationID:  req.LocationID,
+		}); err != nil {
+			return err
+		}
+
+		return r.setStatus(ctx, reservationEntity, dto.ReservationCommitted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Reservation committed: %s", id)
+	return reservationEntity.ToDTO(), nil
+}
+
+func (r *reservationRepo) ReleaseReservation(ctx context.Context, id string) (*dto.ReservationDTO, error) {
+	var reservationEntity *entity.Reservation
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		reservationEntity, err = r.findReservation(ctx, id, true)
+		if err != nil {
+			return err
+		}
+		switch {
+		case reservationEntity.Status == dto.ReservationCommitted:
+			return biz.ErrReservationNotActive
+		case !reservationEntity.Active(nowUTC()):
+			// Already released or expired: nothing is held any more.
+			return nil
+		}
+		return r.setStatus(ctx, reservationEntity, dto.ReservationReleased)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Reservation released: %s", id)
+	return reservationEntity.ToDTO(), nil
+}
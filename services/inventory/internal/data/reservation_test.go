@@ -0,0 +1,94 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestSubtractStockKeepsReservedUnits(t *testing.T) {
+	ctx := context.Background()
+	d := newTestData(t)
+	products := NewInventoryRepo(d, log.DefaultLogger)
+	reservations := NewReservationRepo(d, log.DefaultLogger)
+
+	p, err := products.CreateProduct(ctx, &dto.CreateProductDTO{Name: "Mug", SKU: "MUG-1", Stock: 10, LocationID: dto.DefaultLocationID})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	reservation, err := reservations.ReserveStock(ctx, &dto.ReserveStockDTO{ProductID: p.ID, Quantity: 7, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+
+	subtract := func(qty int32) (*dto.ProductDTO, error) {
+		return products.UpdateStock(ctx, &dto.UpdateStockDTO{ID: p.ID, Quantity: qty, Operation: "subtract", Reason: dto.MovementReasonAdjustment, LocationID: dto.DefaultLocationID})
+	}
+
+	// 3 of the 10 units are available.
+	if _, err := subtract(4); !errors.Is(err, biz.ErrInsufficientStock) {
+		t.Fatalf("subtracting past the available stock: got %v, want ErrInsufficientStock", err)
+	}
+	if _, err := products.BatchUpdateStock(ctx, []*dto.UpdateStockDTO{
+		{ID: p.ID, Quantity: 4, Operation: "subtract", Reason: dto.MovementReasonAdjustment, LocationID: dto.DefaultLocationID},
+	}); err == nil {
+		t.Fatal("batch subtracting past the available stock succeeded")
+	}
+	got, err := subtract(3)
+	if err != nil {
+		t.Fatalf("subtracting the available stock: %v", err)
+	}
+	if got.Stock != 7 || got.AvailableStock != 0 {
+		t.Fatalf("after subtract: stock=%d available=%d, want 7 and 0", got.Stock, got.AvailableStock)
+	}
+
+	// The reservation can still be committed in full.
+	if _, err := reservations.CommitReservation(ctx, &dto.CommitReservationDTO{ID: reservation.ID, LocationID: dto.DefaultLocationID}); err != nil {
+		t.Fatalf("CommitReservation: %v", err)
+	}
+	got, err = products.GetProduct(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if got.Stock != 0 || got.AvailableStock != 0 {
+		t.Fatalf("after commit: stock=%d available=%d, want 0 and 0", got.Stock, got.AvailableStock)
+	}
+}
+
+func TestCommitReservationOfDeletedProduct(t *testing.T) {
+	ctx := context.Background()
+	d := newTestData(t)
+	products := NewInventoryRepo(d, log.DefaultLogger)
+	reservations := NewReservationRepo(d, log.DefaultLogger)
+
+	p, err := products.CreateProduct(ctx, &dto.CreateProductDTO{Name: "Mug", SKU: "MUG-1", Stock: 10, LocationID: dto.DefaultLocationID})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	reservation, err := reservations.ReserveStock(ctx, &dto.ReserveStockDTO{ProductID: p.ID, Quantity: 2, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+	if err := products.DeleteProduct(ctx, p.ID); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+
+	_, err = reservations.CommitReservation(ctx, &dto.CommitReservationDTO{ID: reservation.ID, LocationID: dto.DefaultLocationID})
+	if !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("CommitReservation: got %v, want ErrProductNotFound", err)
+	}
+
+	restored, err := products.RestoreProduct(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("RestoreProduct: %v", err)
+	}
+	if restored.Stock != 10 {
+		t.Fatalf("stock of the deleted product changed to %d", restored.Stock)
+	}
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestSKUOfDeletedProductCanBeReused(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+	create := func(name string) (*dto.ProductDTO, error) {
+		return repo.CreateProduct(ctx, &dto.CreateProductDTO{Name: name, SKU: "MUG-1", LocationID: dto.DefaultLocationID})
+	}
+
+	old, err := create("Old mug")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if _, err := create("Duplicate mug"); !errors.Is(err, biz.ErrSKUAlreadyExists) {
+		t.Fatalf("CreateProduct with a live SKU: got %v, want ErrSKUAlreadyExists", err)
+	}
+	if err := repo.DeleteProduct(ctx, old.ID); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+
+	created, err := create("New mug")
+	if err != nil {
+		t.Fatalf("CreateProduct with the SKU of a deleted product: %v", err)
+	}
+	got, err := repo.GetProductBySku(ctx, "MUG-1")
+	if err != nil {
+		t.Fatalf("GetProductBySku: %v", err)
+	}
+	if got.ID != created.ID {
+		t.Fatalf("GetProductBySku returned %s, want the live product %s", got.ID, created.ID)
+	}
+
+	if _, err := repo.RestoreProduct(ctx, old.ID); !errors.Is(err, biz.ErrSKUAlreadyExists) {
+		t.Fatalf("RestoreProduct over a live SKU: got %v, want ErrSKUAlreadyExists", err)
+	}
+}
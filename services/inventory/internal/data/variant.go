@@ -0,0 +1,130 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/reverny/kratos-mono/pkg/sqldb"
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/data/entity"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func (r *inventoryRepo) ListVariants(ctx context.Context, parentID string) ([]*dto.ProductDTO, error) {
+	parent, err := findProduct(ctx, r.data, parentID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadVariants(ctx, r.data, parent); err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*dto.ProductDTO, len(parent.Variants))
+	for i, variant := range parent.Variants {
+		dtos[i] = variant.ToDTO()
+	}
+	return dtos, nil
+}
+
+// loadDetails attaches what single-product reads return beyond the products
+// row: stock levels, attributes and, for a parent, its variants.
+func (r *inventoryRepo) loadDetails(ctx context.Context, e *entity.Product) error {
+	if err := loadStockLevels(ctx, r.data, e); err != nil {
+		return err
+	}
+	if err := loadAttributes(ctx, r.data, e); err != nil {
+		return err
+	}
+	if e.ParentID != "" {
+		return nil
+	}
+	return loadVariants(ctx, r.data, e)
+}
+
+// loadVariants attaches the live variants of e, oldest first, with their
+// attributes.
+func loadVariants(ctx context.Context, d *Data, e *entity.Product) error {
+	rows, err := d.Conn(ctx).QueryContext(ctx,
+		selectProduct+` WHERE parent_id = ? AND `+notDeleted+` ORDER BY created_at, id`,
+		nowUTC(), e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("list variants %s: %w", e.ID, err)
+	}
+	defer rows.Close()
+
+	e.Variants = []*entity.Product{}
+	for rows.Next() {
+		variant, err := scanProduct(rows)
+		if err != nil {
+			return fmt.Errorf("scan variant: %w", err)
+		}
+		e.Variants = append(e.Variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list variants %s: %w", e.ID, err)
+	}
+	return loadAttributes(ctx, d, e.Variants...)
+}
+
+// loadAttributes attaches their attributes to products in one query.
+func loadAttributes(ctx context.Context, d *Data, products ...*entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*entity.Product, len(products))
+	args := make([]any, len(products))
+	for i, p := range products {
+		p.Attributes = []*entity.Attribute{}
+		byID[p.ID] = p
+		args[i] = p.ID
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(products)), ", ")
+	rows, err := d.Conn(ctx).QueryContext(ctx,
+		`SELECT product_id, name, value_type, value, sort_order FROM product_attributes
+		WHERE product_id IN (`+placeholders+`) ORDER BY product_id, sort_order`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("list attributes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a entity.Attribute
+		if err := rows.Scan(&a.ProductID, &a.Name, &a.Type, &a.Value, &a.SortOrder); err != nil {
+			return fmt.Errorf("scan attribute: %w", err)
+		}
+		p := byID[a.ProductID]
+		p.Attributes = append(p.Attributes, &a)
+	}
+	return rows.Err()
+}
+
+// insertAttributes stores attributes for e in the given order and attaches
+// them to it. It must run inside InTx.
+func insertAttributes(ctx context.Context, d *Data, e *entity.Product, attributes []*dto.AttributeDTO) error {
+	e.Attributes = make([]*entity.Attribute, len(attributes))
+	for i, a := range attributes {
+		e.Attributes[i] = &entity.Attribute{
+			ProductID: e.ID,
+			Name:      a.Name,
+			Type:      a.Type,
+			Value:     a.Value,
+			SortOrder: int32(i),
+		}
+		if _, err := d.Conn(ctx).ExecContext(ctx,
+			`INSERT INTO product_attributes (product_id, name, value_type, value, sort_order) VALUES (?, ?, ?, ?, ?)`,
+			e.ID, a.Name, a.Type, a.Value, i,
+		); err != nil {
+			if sqldb.IsDuplicateKey(err) {
+				return biz.ErrDuplicateAttribute
+			}
+			return fmt.Errorf("create attribute %s: %w", a.Name, err)
+		}
+	}
+	return nil
+}
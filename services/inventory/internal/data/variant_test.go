@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func TestVariants(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+
+	parent, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+		Name:       "T-shirt",
+		SKU:        "TEE",
+		LocationID: dto.DefaultLocationID,
+		Attributes: []*dto.AttributeDTO{{Name: "material", Type: dto.AttributeString, Value: "cotton"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	var variants []*dto.ProductDTO
+	for _, size := range []string{"S", "M"} {
+		variant, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+			Name:       "T-shirt " + size,
+			SKU:        "TEE-" + size,
+			LocationID: dto.DefaultLocationID,
+			ParentID:   parent.ID,
+			Attributes: []*dto.AttributeDTO{{Name: "size", Type: dto.AttributeString, Value: size}},
+		})
+		if err != nil {
+			t.Fatalf("CreateProduct(%s): %v", size, err)
+		}
+		variants = append(variants, variant)
+	}
+
+	if _, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+		Name:       "T-shirt S slim",
+		SKU:        "TEE-S-SLIM",
+		LocationID: dto.DefaultLocationID,
+		ParentID:   variants[0].ID,
+	}); !errors.Is(err, biz.ErrNestedVariant) {
+		t.Fatalf("variant of a variant: got %v, want ErrNestedVariant", err)
+	}
+
+	listed, err := repo.ListVariants(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("ListVariants: %v", err)
+	}
+	// Variants created within the same second have no fixed order.
+	sizes := map[string]string{}
+	for _, v := range listed {
+		if len(v.Attributes) == 1 {
+			sizes[v.SKU] = v.Attributes[0].Value
+		}
+	}
+	if len(listed) != 2 || sizes["TEE-S"] != "S" || sizes["TEE-M"] != "M" {
+		t.Fatalf("ListVariants returned %d variants with sizes %v", len(listed), sizes)
+	}
+
+	got, err := repo.GetProduct(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if len(got.Variants) != 2 || got.Variants[0].ParentID != parent.ID || len(got.Attributes) != 1 || got.Attributes[0].Name != "material" {
+		t.Fatalf("GetProduct returned %+v", got)
+	}
+
+	if err := repo.DeleteProduct(ctx, parent.ID); !errors.Is(err, biz.ErrProductHasVariants) {
+		t.Fatalf("DeleteProduct of a parent: got %v, want ErrProductHasVariants", err)
+	}
+}
+
+func TestUpdateProductReplacesAttributes(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInventoryRepo(newTestData(t), log.DefaultLogger)
+
+	created, err := repo.CreateProduct(ctx, &dto.CreateProductDTO{
+		Name:       "Mug",
+		SKU:        "MUG-1",
+		LocationID: dto.DefaultLocationID,
+		Attributes: []*dto.AttributeDTO{
+			{Name: "color", Type: dto.AttributeString, Value: "red"},
+			{Name: "volume", Type: dto.AttributeInt, Value: "300"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	// Attributes stay when the mask leaves them out.
+	updated, err := repo.UpdateProduct(ctx, &dto.UpdateProductDTO{
+		ID:              created.ID,
+		Name:            "Red mug",
+		ExpectedVersion: 1,
+		UpdateMask:      []string{dto.ProductFieldName},
+	})
+	if err != nil {
+		t.Fatalf("UpdateProduct(name): %v", err)
+	}
+	if len(updated.Attributes) != 2 {
+		t.Fatalf("UpdateProduct(name) returned attributes %+v", updated.Attributes)
+	}
+
+	updated, err = repo.UpdateProduct(ctx, &dto.UpdateProductDTO{
+		ID:              created.ID,
+		ExpectedVersion: 2,
+		Attributes:      []*dto.AttributeDTO{{Name: "dishwasher_safe", Type: dto.AttributeBool, Value: "true"}},
+		UpdateMask:      []string{dto.ProductFieldAttributes},
+	})
+	if err != nil {
+		t.Fatalf("UpdateProduct(attributes): %v", err)
+	}
+	got, err := repo.GetProduct(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	for _, p := range []*dto.ProductDTO{updated, got} {
+		if p.Name != "Red mug" || p.Version != 3 || len(p.Attributes) != 1 || p.Attributes[0].Name != "dishwasher_safe" {
+			t.Fatalf("after replacing attributes: %+v", p)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package dto
+
+// ProductRecordDTO is one row of a product import or export file
+type ProductRecordDTO struct {
+	Row              int32 // 1-based data row of an import file
+	SKU              string
+	Name             string
+	Description      string
+	Price            Money
+	Stock            int32
+	ReorderThreshold int32
+	// Fields lists the ProductField values the row provides; other fields
+	// are left alone when the row updates an existing product.
+	Fields []string
+}
+
+// ProductFieldStock is the stock column of an import file. It only applies
+// to products the import creates.
+const ProductFieldStock = "stock"
+
+// ImportProductsDTO for import options
+type ImportProductsDTO struct {
+	DryRun bool   // validate and report without writing
+	Actor  string // recorded on the initial stock movements
+}
+
+// ImportResultDTO summarises an import
+type ImportResultDTO struct {
+	TotalRows int32
+	Created   int32
+	Updated   int32
+	Failed    int32
+	DryRun    bool
+	Errors    []*ImportRowErrorDTO // the first failures only
+}
+
+// ImportRowErrorDTO reports a row that could not be imported
+type ImportRowErrorDTO struct {
+	Row     int32
+	SKU     string
+	Message string
+}
+
+// ExportProductsQuery for export parameters
+type ExportProductsQuery struct {
+	IncludeDeleted bool
+}
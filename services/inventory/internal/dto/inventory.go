@@ -2,44 +2,139 @@ package dto
 
 import "time"
 
+// DefaultCurrency applies to prices given without a currency, such as the
+// deprecated double price fields. Migration 0009 gave it to every price
+// stored before currencies were recorded, so it must stay "THB".
+const DefaultCurrency = "THB"
+
+// Money is an exact amount: Units whole units plus Nanos (10^-9) of a unit,
+// both of the same sign.
+type Money struct {
+	CurrencyCode string // ISO 4217
+	Units        int64
+	Nanos        int32
+}
+
 // ProductDTO represents product data transfer object for business logic layer
 type ProductDTO struct {
-	ID          string
-	Name        string
-	Description string
-	SKU         string
-	Price       float64
-	Stock       int32
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID               string
+	CatalogID        int64 // id of the product in the catalog service; 0 until linked
+	Name             string
+	Description      string
+	SKU              string
+	Price            Money
+	Stock            int32            // total across locations
+	StockLevels      []*StockLevelDTO // per location; only loaded for single-product reads
+	AvailableStock   int32            // Stock minus active reservations
+	Version          int64
+	ReorderThreshold int32 // 0 means no low-stock alerts
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time // nil unless soft-deleted
+	ParentID         string     // set for variants
+	Attributes       []*AttributeDTO
+	Variants         []*ProductDTO // only loaded for single-product reads of a parent
 }
 
 // CreateProductDTO for creating new product
 type CreateProductDTO struct {
-	Name        string
-	Description string
-	SKU         string
-	Price       float64
-	Stock       int32
+	Name             string
+	Description      string
+	SKU              string
+	Price            Money // an empty CurrencyCode means DefaultCurrency
+	Stock            int32
+	Actor            string // recorded on the initial stock movement
+	ReorderThreshold int32
+	LocationID       string // receives the initial stock
+	ParentID         string // creates a variant of this product
+	Attributes       []*AttributeDTO
+	CatalogID        int64 // set by biz once the catalog knows the SKU
 }
 
 // UpdateProductDTO for updating product
 type UpdateProductDTO struct {
-	ID          string
-	Name        string
-	Description string
-	Price       float64
+	ID               string
+	Name             string
+	Description      string
+	Price            Money           // an empty CurrencyCode means DefaultCurrency
+	ExpectedVersion  int64           // 0 means unconditional
+	ReorderThreshold *int32          // nil keeps the current threshold
+	CatalogID        int64           // written when ProductFieldCatalogID is masked
+	Attributes       []*AttributeDTO // replace all attributes when ProductFieldAttributes is masked
+	// UpdateMask lists the ProductField values to write. biz fills it in
+	// when the request carried no mask.
+	UpdateMask []string
 }
 
+// Product fields accepted in UpdateProductDTO.UpdateMask
+const (
+	ProductFieldName             = "name"
+	ProductFieldDescription      = "description"
+	ProductFieldPrice            = "price" // "unit_price" is accepted as an alias
+	ProductFieldReorderThreshold = "reorder_threshold"
+	ProductFieldAttributes       = "attributes"
+	// ProductFieldCatalogID is set by biz only, when it links a product to
+	// the catalog; clients cannot mask it.
+	ProductFieldCatalogID = "catalog_id"
+)
+
 // UpdateStockDTO for stock operations
 type UpdateStockDTO struct {
-	ID        string
-	Quantity  int32
-	Operation string // "add" or "subtract"
+	ID              string
+	Quantity        int32
+	Operation       string // "add" or "subtract"
+	ExpectedVersion int64  // 0 means unconditional
+	Reason          string // stock movement reason code
+	ReferenceID     string
+	Actor           string
+	LocationID      string
 }
 
+// Sortable product fields accepted in ListProductsQuery.OrderBy
+const (
+	ProductSortName      = "name"
+	ProductSortSKU       = "sku"
+	ProductSortPrice     = "price"
+	ProductSortStock     = "stock"
+	ProductSortCreatedAt = "created_at"
+	ProductSortUpdatedAt = "updated_at"
+)
+
 // ListProductsQuery for list query parameters
 type ListProductsQuery struct {
 	Page     int32
 	PageSize int32
+	Search   string   // name or SKU substring
+	MinPrice *float64 // nil means unbounded
+	MaxPrice *float64 // nil means unbounded
+	InStock  *bool    // nil means any stock level
+	OrderBy  string   // "<field> [asc|desc]"; biz normalises it to a ProductSort constant
+	Desc     bool     // set by biz from OrderBy
+	// PageToken continues from a previous page and takes precedence over Page.
+	PageToken      string
+	IncludeDeleted bool // also list soft-deleted products
+	SkipTotal      bool // leave PageDTO.Total at 0 instead of counting
+}
+
+// PageDTO describes the page returned by a list query
+type PageDTO struct {
+	Total         int32 // only counted for offset paging
+	NextPageToken string
+}
+
+// ListLowStockProductsQuery for low-stock list query parameters
+type ListLowStockProductsQuery struct {
+	Page     int32
+	PageSize int32
+}
+
+// LowStockEventDTO is emitted when a stock change takes a product to or
+// below its reorder threshold.
+type LowStockEventDTO struct {
+	ProductID        string
+	SKU              string
+	Name             string
+	Stock            int32
+	ReorderThreshold int32
+	OccurredAt       time.Time
 }
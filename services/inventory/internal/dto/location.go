@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// DefaultLocationID is used for stock changes that name no location.
+const DefaultLocationID = "default"
+
+// LocationDTO represents a warehouse or other stock location
+type LocationDTO struct {
+	ID        string
+	Code      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CreateLocationDTO for creating new location
+type CreateLocationDTO struct {
+	Code string
+	Name string
+}
+
+// StockLevelDTO is the quantity of a product held at one location
+type StockLevelDTO struct {
+	LocationID string
+	Quantity   int32
+}
+
+// TransferStockDTO for moving stock between locations
+type TransferStockDTO struct {
+	ProductID      string
+	FromLocationID string
+	ToLocationID   string
+	Quantity       int32
+	ReferenceID    string
+	Actor          string
+}
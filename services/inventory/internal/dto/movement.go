@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// Stock movement reason codes recorded by the service itself
+const (
+	MovementReasonInitialStock      = "initial_stock"
+	MovementReasonAdjustment        = "adjustment"
+	MovementReasonReservationCommit = "reservation_commit"
+	MovementReasonTransferOut       = "transfer_out"
+	MovementReasonTransferIn        = "transfer_in"
+)
+
+// StockMovementDTO represents an immutable stock ledger entry
+type StockMovementDTO struct {
+	ID          string
+	ProductID   string
+	Delta       int32
+	StockAfter  int32
+	Reason      string
+	ReferenceID string
+	Actor       string
+	LocationID  string
+	CreatedAt   time.Time
+}
+
+// ListStockMovementsQuery for ledger query parameters
+type ListStockMovementsQuery struct {
+	ProductID string
+	StartTime time.Time // inclusive, zero means unbounded
+	EndTime   time.Time // exclusive, zero means unbounded
+	Page      int32
+	PageSize  int32
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// Reservation statuses
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// ReservationDTO represents a stock reservation for business logic layer
+type ReservationDTO struct {
+	ID          string
+	ProductID   string
+	Quantity    int32
+	ReferenceID string
+	Status      string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReserveStockDTO for holding stock before checkout completes
+type ReserveStockDTO struct {
+	ProductID   string
+	Quantity    int32
+	ReferenceID string
+	TTL         time.Duration
+}
+
+// CommitReservationDTO for turning a reservation into a stock deduction
+type CommitReservationDTO struct {
+	ID         string
+	Actor      string
+	LocationID string // the location the stock leaves from
+}
@@ -0,0 +1,17 @@
+package dto
+
+// Attribute value types
+const (
+	AttributeString = "string"
+	AttributeInt    = "int"
+	AttributeDouble = "double"
+	AttributeBool   = "bool"
+)
+
+// AttributeDTO is a typed product attribute. Value holds the text form of
+// the value: strconv formatting for numbers and booleans.
+type AttributeDTO struct {
+	Name  string
+	Type  string // one of the Attribute* constants
+	Value string
+}
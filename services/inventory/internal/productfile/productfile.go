@@ -0,0 +1,384 @@
+// Package productfile reads and writes the CSV and NDJSON files used to bulk
+// import and export inventory products.
+//
+// Both formats carry the same columns. CSV files start with a header row
+// naming the columns present, in any order; NDJSON files hold one JSON
+// object per line keyed by column name. Prices are exact decimal amounts,
+// written as strings in NDJSON.
+package productfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/reverny/kratos-mono/pkg/money"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+// Supported file formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// File columns, in the order they are exported
+const (
+	ColumnSKU              = "sku"
+	ColumnName             = "name"
+	ColumnDescription      = "description"
+	ColumnPrice            = "price"
+	ColumnCurrencyCode     = "currency_code"
+	ColumnStock            = "stock"
+	ColumnReorderThreshold = "reorder_threshold"
+)
+
+// Columns lists every column in export order.
+var Columns = []string{
+	ColumnSKU, ColumnName, ColumnDescription, ColumnPrice,
+	ColumnCurrencyCode, ColumnStock, ColumnReorderThreshold,
+}
+
+// maxLineSize bounds a single NDJSON line.
+const maxLineSize = 1 << 20
+
+// ErrUnknownFormat is returned for formats other than FormatCSV and FormatNDJSON.
+var ErrUnknownFormat = errors.New("unknown file format")
+
+// FormatFromFilename guesses the format from a file extension, returning
+// "" when the extension is not recognised.
+func FormatFromFilename(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return FormatCSV
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	}
+	return ""
+}
+
+// RowError reports a row that could not be decoded. Reading can continue
+// with the next row.
+type RowError struct {
+	Row int32
+	SKU string
+	Err error
+}
+
+func (e *RowError) Error() string { return fmt.Sprintf("row %d: %v", e.Row, e.Err) }
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+// Reader decodes product records from an import file.
+type Reader struct {
+	format  string
+	csv     *csv.Reader
+	columns []string // CSV header
+	lines   *bufio.Scanner
+	row     int32
+}
+
+// NewReader returns a Reader decoding r in the given format.
+func NewReader(r io.Reader, format string) (*Reader, error) {
+	rd := &Reader{format: format}
+	switch format {
+	case FormatCSV:
+		rd.csv = csv.NewReader(r)
+		rd.csv.FieldsPerRecord = -1
+		rd.csv.TrimLeadingSpace = true
+	case FormatNDJSON:
+		rd.lines = bufio.NewScanner(r)
+		rd.lines.Buffer(make([]byte, 64*1024), maxLineSize)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	return rd, nil
+}
+
+// Read returns the next record, or io.EOF after the last one. A *RowError
+// leaves the reader positioned at the following row; any other error is
+// fatal.
+func (r *Reader) Read() (*dto.ProductRecordDTO, error) {
+	if r.format == FormatCSV {
+		return r.readCSV()
+	}
+	return r.readNDJSON()
+}
+
+func (r *Reader) readCSV() (*dto.ProductRecordDTO, error) {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := r.csv.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	r.row++
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, &RowError{Row: r.row, Err: parseErr.Err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &dto.ProductRecordDTO{Row: r.row}
+	if len(fields) != len(r.columns) {
+		return nil, r.rowError(rec, fmt.Errorf("expected %d columns, got %d", len(r.columns), len(fields)))
+	}
+	var currency string
+	for i, column := range r.columns {
+		value := fields[i]
+		if column == ColumnCurrencyCode {
+			currency = strings.TrimSpace(value)
+			continue
+		}
+		if err := setColumn(rec, column, value, true); err != nil {
+			return nil, r.rowError(rec, err)
+		}
+	}
+	rec.Price.CurrencyCode = currency
+	return rec, nil
+}
+
+func (r *Reader) readHeader() error {
+	header, err := r.csv.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+
+	seen := make(map[string]bool, len(header))
+	r.columns = make([]string, len(header))
+	for i, column := range header {
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark.
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !isColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+		if seen[column] {
+			return fmt.Errorf("duplicate column %q", column)
+		}
+		seen[column] = true
+		r.columns[i] = column
+	}
+	if !seen[ColumnSKU] {
+		return fmt.Errorf("missing column %q", ColumnSKU)
+	}
+	return nil
+}
+
+func (r *Reader) readNDJSON() (*dto.ProductRecordDTO, error) {
+	var line []byte
+	for len(line) == 0 {
+		if !r.lines.Scan() {
+			if err := r.lines.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		line = bytes.TrimSpace(r.lines.Bytes())
+	}
+	r.row++
+
+	rec := &dto.ProductRecordDTO{Row: r.row}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, r.rowError(rec, fmt.Errorf("invalid JSON: %w", err))
+	}
+	if raw, ok := object[ColumnSKU]; ok {
+		// Known early so that later errors can name the SKU.
+		_ = json.Unmarshal(raw, &rec.SKU)
+		rec.SKU = strings.TrimSpace(rec.SKU)
+	}
+
+	for _, column := range Columns {
+		raw, ok := object[column]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		delete(object, column)
+
+		var value string
+		quoted := len(raw) > 0 && raw[0] == '"'
+		if quoted {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, r.rowError(rec, fmt.Errorf("%s: %w", column, err))
+			}
+		} else {
+			value = string(raw)
+		}
+		if column == ColumnCurrencyCode {
+			rec.Price.CurrencyCode = strings.TrimSpace(value)
+			continue
+		}
+		if err := setColumn(rec, column, value, quoted); err != nil {
+			return nil, r.rowError(rec, err)
+		}
+	}
+	for key := range object {
+		if !isColumn(key) {
+			return nil, r.rowError(rec, fmt.Errorf("unknown field %q", key))
+		}
+	}
+	return rec, nil
+}
+
+func (r *Reader) rowError(rec *dto.ProductRecordDTO, err error) error {
+	return &RowError{Row: rec.Row, SKU: rec.SKU, Err: err}
+}
+
+func isColumn(name string) bool {
+	for _, column := range Columns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setColumn decodes one column into rec and marks its field as provided.
+// Blank numeric cells count as not provided. Strings must be quoted in
+// NDJSON, numbers may be either.
+func setColumn(rec *dto.ProductRecordDTO, column, value string, quoted bool) error {
+	if !quoted && column != ColumnPrice && column != ColumnStock && column != ColumnReorderThreshold {
+		return fmt.Errorf("%s must be a string", column)
+	}
+
+	switch column {
+	case ColumnSKU:
+		rec.SKU = strings.TrimSpace(value)
+		return nil
+	case ColumnName:
+		rec.Name = strings.TrimSpace(value)
+		rec.Fields = append(rec.Fields, dto.ProductFieldName)
+		return nil
+	case ColumnDescription:
+		rec.Description = value
+		rec.Fields = append(rec.Fields, dto.ProductFieldDescription)
+		return nil
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	switch column {
+	case ColumnPrice:
+		units, nanos, err := money.Parse(value)
+		if err != nil {
+			return fmt.Errorf("price: %w", err)
+		}
+		rec.Price.Units, rec.Price.Nanos = units, nanos
+		rec.Fields = append(rec.Fields, dto.ProductFieldPrice)
+	case ColumnStock:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("stock: invalid integer %q", value)
+		}
+		rec.Stock = int32(n)
+		rec.Fields = append(rec.Fields, dto.ProductFieldStock)
+	case ColumnReorderThreshold:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("reorder_threshold: invalid integer %q", value)
+		}
+		rec.ReorderThreshold = int32(n)
+		rec.Fields = append(rec.Fields, dto.ProductFieldReorderThreshold)
+	}
+	return nil
+}
+
+// Writer encodes products into an export file.
+type Writer struct {
+	csv         *csv.Writer
+	json        *json.Encoder
+	wroteHeader bool
+}
+
+// ndjsonRecord fixes the key order of NDJSON lines.
+type ndjsonRecord struct {
+	SKU              string `json:"sku"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	Price            string `json:"price"`
+	CurrencyCode     string `json:"currency_code"`
+	Stock            int32  `json:"stock"`
+	ReorderThreshold int32  `json:"reorder_threshold"`
+}
+
+// NewWriter returns a Writer encoding to w in the given format.
+func NewWriter(w io.Writer, format string) (*Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &Writer{csv: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &Writer{json: enc}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Write encodes p as the next record.
+func (w *Writer) Write(p *dto.ProductDTO) error {
+	price := money.Format(p.Price.Units, p.Price.Nanos)
+	if w.json != nil {
+		return w.json.Encode(&ndjsonRecord{
+			SKU:              p.SKU,
+			Name:             p.Name,
+			Description:      p.Description,
+			Price:            price,
+			CurrencyCode:     p.Price.CurrencyCode,
+			Stock:            p.Stock,
+			ReorderThreshold: p.ReorderThreshold,
+		})
+	}
+
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if err := w.csv.Write(Columns); err != nil {
+			return err
+		}
+	}
+	return w.csv.Write([]string{
+		p.SKU,
+		p.Name,
+		p.Description,
+		price,
+		p.Price.CurrencyCode,
+		strconv.Itoa(int(p.Stock)),
+		strconv.Itoa(int(p.ReorderThreshold)),
+	})
+}
+
+// Flush writes any buffered data. A CSV export of no products still gets
+// its header row.
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if err := w.csv.Write(Columns); err != nil {
+			return err
+		}
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
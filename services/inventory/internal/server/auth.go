@@ -0,0 +1,17 @@
+package server
+
+import (
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+)
+
+// policy holds the roles required by the RPCs annotated with
+// (api.auth.required_role) in inventory.proto.
+var policy = auth.MustPolicy(v1.File_inventory_v1_inventory_proto)
+
+// NewVerifier returns the access token verifier shared by the gRPC and HTTP
+// servers.
+func NewVerifier(c *conf.Server) (*auth.Verifier, error) {
+	return auth.NewVerifierFromConfig(c.GetAuth())
+}
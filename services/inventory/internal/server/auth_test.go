@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/auth/authztest"
+)
+
+func TestPolicy(t *testing.T) {
+	authztest.Run(t, policy, []authztest.Case{
+		{Operation: v1.Inventory_CreateProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_CreateProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_GetProduct_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_GetProductBySku_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_GetProductByCatalogId_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ListProducts_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ListLowStockProducts_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_UpdateProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_UpdateProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_DeleteProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_DeleteProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_RestoreProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_RestoreProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_UpdateStock_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_BatchUpdateStock_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ReserveStock_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_CommitReservation_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ReleaseReservation_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ListStockMovements_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_TransferStock_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_CreateLocation_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_CreateLocation_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_ListLocations_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_CreateVariant_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_CreateVariant_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_ListVariants_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Inventory_ImportProducts_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Inventory_ImportProducts_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Inventory_ExportProducts_FullMethodName, Role: auth.RoleUser, Allowed: true},
+	})
+}
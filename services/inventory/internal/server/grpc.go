@@ -6,16 +6,22 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/middleware/idempotency"
 	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
 	"github.com/reverny/kratos-mono/services/inventory/internal/service"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, inventoryService *service.InventoryService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, verifier *auth.Verifier, ic *conf.Inventory, store idempotency.Store, inventoryService *service.InventoryService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			auth.Middleware(verifier, policy),
+			idempotencyMiddleware(ic, store, logger),
 		),
+		// Import and export are streams, which grpc.Middleware does not cover.
+		grpc.StreamInterceptor(auth.StreamServerInterceptor(auth.Middleware(verifier, policy))),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
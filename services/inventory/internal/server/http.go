@@ -9,6 +9,8 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/middleware/idempotency"
 	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
 	"github.com/reverny/kratos-mono/services/inventory/internal/service"
 )
@@ -17,10 +19,12 @@ import (
 var swaggerHTML []byte
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, inventoryService *service.InventoryService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, verifier *auth.Verifier, ic *conf.Inventory, store idempotency.Store, inventoryService *service.InventoryService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(),
+			auth.Middleware(verifier, policy),
+			idempotencyMiddleware(ic, store, logger),
 		),
 	}
 	if c.Http.Network != "" {
@@ -34,6 +38,11 @@ func NewHTTPServer(c *conf.Server, inventoryService *service.InventoryService, l
 	}
 	srv := http.NewServer(opts...)
 	v1.RegisterInventoryHTTPServer(srv, inventoryService)
+
+	// Bulk file transfer, which the generated handlers cannot express
+	r := srv.Route("/")
+	r.POST("/v1/products:import", inventoryService.ImportProductsHTTP)
+	r.GET("/v1/products:export", inventoryService.ExportProductsHTTP)
 	
 	// Serve Swagger UI
 	srv.HandleFunc("/docs", func(w nethttp.ResponseWriter, r *nethttp.Request) {
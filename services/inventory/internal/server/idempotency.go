@@ -0,0 +1,37 @@
+package server
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/middleware/idempotency"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+)
+
+// idempotentOperations are the mutating RPCs that honour an Idempotency-Key.
+var idempotentOperations = []string{
+	v1.Inventory_CreateProduct_FullMethodName,
+	v1.Inventory_CreateVariant_FullMethodName,
+	v1.Inventory_UpdateStock_FullMethodName,
+	v1.Inventory_BatchUpdateStock_FullMethodName,
+	v1.Inventory_TransferStock_FullMethodName,
+	v1.Inventory_DeleteProduct_FullMethodName,
+}
+
+// NewIdempotencyStore returns the store shared by the gRPC and HTTP servers,
+// so a retry is deduplicated whichever transport it arrives on.
+func NewIdempotencyStore() idempotency.Store {
+	return idempotency.NewMemoryStore()
+}
+
+func idempotencyMiddleware(c *conf.Inventory, store idempotency.Store, logger log.Logger) middleware.Middleware {
+	return selector.Server(
+		idempotency.Server(
+			idempotency.WithStore(store),
+			idempotency.WithTTL(c.GetIdempotencyTtl().AsDuration()),
+			idempotency.WithLogger(logger),
+		),
+	).Path(idempotentOperations...).Build()
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
+	"github.com/reverny/kratos-mono/services/inventory/internal/conf"
+)
+
+// defaultPurgeInterval applies when conf.Inventory.PurgeInterval is unset.
+const defaultPurgeInterval = time.Hour
+
+// PurgeServer periodically hard-deletes products whose soft-delete retention
+// has elapsed. It runs alongside the gRPC and HTTP servers as a
+// transport.Server so the app starts and stops it with them.
+type PurgeServer struct {
+	uc        *biz.InventoryUsecase
+	retention time.Duration
+	interval  time.Duration
+	log       *log.Helper
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPurgeServer new a purge job server.
+func NewPurgeServer(c *conf.Inventory, uc *biz.InventoryUsecase, logger log.Logger) *PurgeServer {
+	interval := c.GetPurgeInterval().AsDuration()
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	return &PurgeServer{
+		uc:        uc,
+		retention: c.GetDeletedProductRetention().AsDuration(),
+		interval:  interval,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// Start runs the purge loop until Stop is called. A zero retention keeps
+// deleted products forever and disables the job.
+func (s *PurgeServer) Start(ctx context.Context) error {
+	if s.retention <= 0 {
+		s.log.Info("product purge disabled: no deleted_product_retention configured")
+		return nil
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.purge(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the purge loop and waits for a running purge to finish.
+func (s *PurgeServer) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *PurgeServer) purge(ctx context.Context) {
+	if _, err := s.uc.PurgeDeletedProducts(ctx, s.retention); err != nil && ctx.Err() == nil {
+		s.log.Errorf("purge deleted products: %v", err)
+	}
+}
@@ -3,4 +3,4 @@ package server
 import "github.com/google/wire"
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewVerifier, NewPurgeServer, NewIdempotencyStore)
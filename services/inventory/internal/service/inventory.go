@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/reverny/kratos-mono/gen/go/api/common"
 	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/money"
+	"github.com/reverny/kratos-mono/pkg/pagination"
 	"github.com/reverny/kratos-mono/services/inventory/internal/biz"
 	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
 )
@@ -13,11 +19,17 @@ import (
 type InventoryService struct {
 	v1.UnimplementedInventoryServer
 
-	uc *biz.InventoryUsecase
+	uc          *biz.InventoryUsecase
+	reservation *biz.ReservationUsecase
+	location    *biz.LocationUsecase
 }
 
-func NewInventoryService(uc *biz.InventoryUsecase) *InventoryService {
-	return &InventoryService{uc: uc}
+func NewInventoryService(uc *biz.InventoryUsecase, reservation *biz.ReservationUsecase, location *biz.LocationUsecase) *InventoryService {
+	return &InventoryService{
+		uc:          uc,
+		reservation: reservation,
+		location:    location,
+	}
 }
 
 func (s *InventoryService) CreateProduct(ctx context.Context, req *v1.CreateProductRequest) (*v1.Product, error) {
@@ -26,8 +38,11 @@ func (s *InventoryService) CreateProduct(ctx context.Context, req *v1.CreateProd
 		Name:        req.Name,
 		Description: req.Description,
 		SKU:         req.Sku,
-		Price:       req.Price,
+		Price:       priceToDTO(req.UnitPrice, req.Price),
 		Stock:       req.Stock,
+		Actor:       actorFromContext(ctx),
+		LocationID:  req.LocationId,
+		Attributes:  attributesToDTO(req.Attributes),
 	}
 
 	// Call business logic with DTO
@@ -48,35 +63,86 @@ func (s *InventoryService) GetProduct(ctx context.Context, req *v1.GetProductReq
 	return dtoToProto(productDTO), nil
 }
 
+func (s *InventoryService) GetProductBySku(ctx context.Context, req *v1.GetProductBySkuRequest) (*v1.Product, error) {
+	productDTO, err := s.uc.GetProductBySku(ctx, req.Sku)
+	if err != nil {
+		return nil, err
+	}
+	return dtoToProto(productDTO), nil
+}
+
+func (s *InventoryService) GetProductByCatalogId(ctx context.Context, req *v1.GetProductByCatalogIdRequest) (*v1.Product, error) {
+	productDTO, err := s.uc.GetProductByCatalogID(ctx, req.CatalogId)
+	if err != nil {
+		return nil, err
+	}
+	return dtoToProto(productDTO), nil
+}
+
 func (s *InventoryService) ListProducts(ctx context.Context, req *v1.ListProductsRequest) (*v1.ListProductsResponse, error) {
 	query := &dto.ListProductsQuery{
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+		Search:         req.Search,
+		MinPrice:       req.MinPrice,
+		MaxPrice:       req.MaxPrice,
+		InStock:        req.InStock,
+		OrderBy:        req.OrderBy,
+		PageToken:      req.PageToken,
+		IncludeDeleted: req.IncludeDeleted,
+	}
+
+	products, pageInfo, err := s.uc.ListProducts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert DTOs to proto
+	protoProducts := make([]*v1.Product, len(products))
+	for i, p := range products {
+		protoProducts[i] = dtoToProto(p)
+	}
+
+	return &v1.ListProductsResponse{
+		Products:   protoProducts,
+		Total:      pageInfo.Total,
+		Pagination: pagination.Proto(query.Page, query.PageSize, pageInfo.Total, pageInfo.NextPageToken),
+	}, nil
+}
+
+func (s *InventoryService) ListLowStockProducts(ctx context.Context, req *v1.ListLowStockProductsRequest) (*v1.ListProductsResponse, error) {
+	query := &dto.ListLowStockProductsQuery{
 		Page:     req.Page,
 		PageSize: req.PageSize,
 	}
 
-	products, total, err := s.uc.ListProducts(ctx, query)
+	products, total, err := s.uc.ListLowStockProducts(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert DTOs to proto
 	protoProducts := make([]*v1.Product, len(products))
 	for i, p := range products {
 		protoProducts[i] = dtoToProto(p)
 	}
 
 	return &v1.ListProductsResponse{
-		Products: protoProducts,
-		Total:    total,
+		Products:   protoProducts,
+		Total:      total,
+		Pagination: pagination.Proto(query.Page, query.PageSize, total, ""),
 	}, nil
 }
 
 func (s *InventoryService) UpdateProduct(ctx context.Context, req *v1.UpdateProductRequest) (*v1.Product, error) {
 	updateDTO := &dto.UpdateProductDTO{
-		ID:          req.Id,
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
+		ID:               req.Id,
+		Name:             req.Name,
+		Description:      req.Description,
+		Price:            priceToDTO(req.UnitPrice, req.Price),
+		ExpectedVersion:  req.ExpectedVersion,
+		ReorderThreshold: req.ReorderThreshold,
+		Attributes:       attributesToDTO(req.Attributes),
+		UpdateMask:       req.GetUpdateMask().GetPaths(),
 	}
 
 	productDTO, err := s.uc.UpdateProduct(ctx, updateDTO)
@@ -95,14 +161,16 @@ func (s *InventoryService) DeleteProduct(ctx context.Context, req *v1.DeleteProd
 	return &emptypb.Empty{}, nil
 }
 
-func (s *InventoryService) UpdateStock(ctx context.Context, req *v1.UpdateStockRequest) (*v1.Product, error) {
-	stockDTO := &dto.UpdateStockDTO{
-		ID:        req.Id,
-		Quantity:  req.Quantity,
-		Operation: req.Operation,
+func (s *InventoryService) RestoreProduct(ctx context.Context, req *v1.RestoreProductRequest) (*v1.Product, error) {
+	productDTO, err := s.uc.RestoreProduct(ctx, req.Id)
+	if err != nil {
+		return nil, err
 	}
+	return dtoToProto(productDTO), nil
+}
 
-	productDTO, err := s.uc.UpdateStock(ctx, stockDTO)
+func (s *InventoryService) UpdateStock(ctx context.Context, req *v1.UpdateStockRequest) (*v1.Product, error) {
+	productDTO, err := s.uc.UpdateStock(ctx, stockRequestToDTO(ctx, req))
 	if err != nil {
 		return nil, err
 	}
@@ -110,16 +178,205 @@ func (s *InventoryService) UpdateStock(ctx context.Context, req *v1.UpdateStockR
 	return dtoToProto(productDTO), nil
 }
 
+func (s *InventoryService) BatchUpdateStock(ctx context.Context, req *v1.BatchUpdateStockRequest) (*v1.BatchUpdateStockResponse, error) {
+	items := make([]*dto.UpdateStockDTO, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = stockRequestToDTO(ctx, item)
+	}
+
+	products, err := s.uc.BatchUpdateStock(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	protoProducts := make([]*v1.Product, len(products))
+	for i, p := range products {
+		protoProducts[i] = dtoToProto(p)
+	}
+
+	return &v1.BatchUpdateStockResponse{
+		Products: protoProducts,
+	}, nil
+}
+
+func (s *InventoryService) ReserveStock(ctx context.Context, req *v1.ReserveStockRequest) (*v1.Reservation, error) {
+	reserveDTO := &dto.ReserveStockDTO{
+		ProductID:   req.ProductId,
+		Quantity:    req.Quantity,
+		ReferenceID: req.ReferenceId,
+	}
+
+	reservationDTO, err := s.reservation.ReserveStock(ctx, reserveDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservationToProto(reservationDTO), nil
+}
+
+func (s *InventoryService) CommitReservation(ctx context.Context, req *v1.CommitReservationRequest) (*v1.Reservation, error) {
+	commitDTO := &dto.CommitReservationDTO{
+		ID:         req.Id,
+		Actor:      actorFromContext(ctx),
+		LocationID: req.LocationId,
+	}
+
+	reservationDTO, err := s.reservation.CommitReservation(ctx, commitDTO)
+	if err != nil {
+		return nil, err
+	}
+	return reservationToProto(reservationDTO), nil
+}
+
+func (s *InventoryService) ReleaseReservation(ctx context.Context, req *v1.ReleaseReservationRequest) (*v1.Reservation, error) {
+	reservationDTO, err := s.reservation.ReleaseReservation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return reservationToProto(reservationDTO), nil
+}
+
+func (s *InventoryService) ListStockMovements(ctx context.Context, req *v1.ListStockMovementsRequest) (*v1.ListStockMovementsResponse, error) {
+	query := &dto.ListStockMovementsQuery{
+		ProductID: req.ProductId,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+	var err error
+	if query.StartTime, err = parseTime("start_time", req.StartTime); err != nil {
+		return nil, err
+	}
+	if query.EndTime, err = parseTime("end_time", req.EndTime); err != nil {
+		return nil, err
+	}
+
+	movements, total, err := s.uc.ListStockMovements(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	protoMovements := make([]*v1.StockMovement, len(movements))
+	for i, m := range movements {
+		protoMovements[i] = movementToProto(m)
+	}
+
+	return &v1.ListStockMovementsResponse{
+		Movements: protoMovements,
+		Total:     total,
+	}, nil
+}
+
+func stockRequestToDTO(ctx context.Context, req *v1.UpdateStockRequest) *dto.UpdateStockDTO {
+	return &dto.UpdateStockDTO{
+		ID:              req.Id,
+		Quantity:        req.Quantity,
+		Operation:       req.Operation,
+		ExpectedVersion: req.ExpectedVersion,
+		Reason:          req.Reason,
+		ReferenceID:     req.ReferenceId,
+		Actor:           actorFromContext(ctx),
+		LocationID:      req.LocationId,
+	}
+}
+
+// actorFromContext identifies the caller recorded on stock movements: the
+// user id of the verified access token.
+func actorFromContext(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.UserID()
+	}
+	return ""
+}
+
+// parseTime parses an optional RFC3339 request field.
+func parseTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), field+" must be an RFC3339 timestamp")
+	}
+	return t, nil
+}
+
 // Helper function to convert DTO to proto
+// priceToDTO takes unit_price when set and otherwise the deprecated double
+// price, which carries no currency.
+func priceToDTO(unitPrice *common.Money, legacy float64) dto.Money {
+	if unitPrice != nil {
+		return dto.Money{
+			CurrencyCode: unitPrice.CurrencyCode,
+			Units:        unitPrice.Units,
+			Nanos:        unitPrice.Nanos,
+		}
+	}
+	units, nanos := money.FromFloat(legacy)
+	return dto.Money{Units: units, Nanos: nanos}
+}
+
+func moneyToProto(m dto.Money) *common.Money {
+	return &common.Money{
+		CurrencyCode: m.CurrencyCode,
+		Units:        m.Units,
+		Nanos:        m.Nanos,
+	}
+}
+
 func dtoToProto(dto *dto.ProductDTO) *v1.Product {
-	return &v1.Product{
+	p := &v1.Product{
+		Id:               dto.ID,
+		Name:             dto.Name,
+		Description:      dto.Description,
+		Sku:              dto.SKU,
+		Price:            money.Float64(dto.Price.Units, dto.Price.Nanos),
+		UnitPrice:        moneyToProto(dto.Price),
+		ParentId:         dto.ParentID,
+		CatalogId:        dto.CatalogID,
+		Attributes:       attributesToProto(dto.Attributes),
+		Stock:            dto.Stock,
+		AvailableStock:   dto.AvailableStock,
+		Version:          dto.Version,
+		ReorderThreshold: dto.ReorderThreshold,
+		StockLevels:      stockLevelsToProto(dto.StockLevels),
+		CreatedAt:        dto.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        dto.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if len(dto.Variants) > 0 {
+		p.Variants = make([]*v1.Product, len(dto.Variants))
+		for i, v := range dto.Variants {
+			p.Variants[i] = dtoToProto(v)
+		}
+	}
+	if dto.DeletedAt != nil {
+		p.DeletedAt = dto.DeletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return p
+}
+
+func reservationToProto(dto *dto.ReservationDTO) *v1.Reservation {
+	return &v1.Reservation{
 		Id:          dto.ID,
-		Name:        dto.Name,
-		Description: dto.Description,
-		Sku:         dto.SKU,
-		Price:       dto.Price,
-		Stock:       dto.Stock,
+		ProductId:   dto.ProductID,
+		Quantity:    dto.Quantity,
+		ReferenceId: dto.ReferenceID,
+		Status:      dto.Status,
+		ExpiresAt:   dto.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
 		CreatedAt:   dto.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   dto.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
+
+func movementToProto(dto *dto.StockMovementDTO) *v1.StockMovement {
+	return &v1.StockMovement{
+		Id:          dto.ID,
+		ProductId:   dto.ProductID,
+		Delta:       dto.Delta,
+		StockAfter:  dto.StockAfter,
+		Reason:      dto.Reason,
+		ReferenceId: dto.ReferenceID,
+		Actor:       dto.Actor,
+		CreatedAt:   dto.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		LocationId:  dto.LocationID,
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/reverny/kratos-mono/pkg/auth"
+)
+
+func TestActorFromContextUsesTokenSubject(t *testing.T) {
+	if got := actorFromContext(context.Background()); got != "" {
+		t.Fatalf("actor without claims = %q, want empty", got)
+	}
+
+	ctx := auth.NewContext(context.Background(), &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Username:         "alice",
+	}, "token")
+	if got := actorFromContext(ctx); got != "user-1" {
+		t.Fatalf("actor = %q, want the token subject user-1", got)
+	}
+}
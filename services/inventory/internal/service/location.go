@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func (s *InventoryService) TransferStock(ctx context.Context, req *v1.TransferStockRequest) (*v1.Product, error) {
+	transferDTO := &dto.TransferStockDTO{
+		ProductID:      req.ProductId,
+		FromLocationID: req.FromLocationId,
+		ToLocationID:   req.ToLocationId,
+		Quantity:       req.Quantity,
+		ReferenceID:    req.ReferenceId,
+		Actor:          actorFromContext(ctx),
+	}
+
+	productDTO, err := s.uc.TransferStock(ctx, transferDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtoToProto(productDTO), nil
+}
+
+func (s *InventoryService) CreateLocation(ctx context.Context, req *v1.CreateLocationRequest) (*v1.Location, error) {
+	locationDTO, err := s.location.CreateLocation(ctx, &dto.CreateLocationDTO{
+		Code: req.Code,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locationToProto(locationDTO), nil
+}
+
+func (s *InventoryService) ListLocations(ctx context.Context, req *v1.ListLocationsRequest) (*v1.ListLocationsResponse, error) {
+	locations, err := s.location.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protoLocations := make([]*v1.Location, len(locations))
+	for i, l := range locations {
+		protoLocations[i] = locationToProto(l)
+	}
+
+	return &v1.ListLocationsResponse{
+		Locations: protoLocations,
+	}, nil
+}
+
+func locationToProto(dto *dto.LocationDTO) *v1.Location {
+	return &v1.Location{
+		Id:        dto.ID,
+		Code:      dto.Code,
+		Name:      dto.Name,
+		CreatedAt: dto.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: dto.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func stockLevelsToProto(levels []*dto.StockLevelDTO) []*v1.StockLevel {
+	protoLevels := make([]*v1.StockLevel, len(levels))
+	for i, level := range levels {
+		protoLevels[i] = &v1.StockLevel{
+			LocationId: level.LocationID,
+			Quantity:   level.Quantity,
+		}
+	}
+	return protoLevels
+}
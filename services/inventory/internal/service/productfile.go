@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+	"github.com/reverny/kratos-mono/services/inventory/internal/productfile"
+)
+
+// exportChunkSize is the size of the chunks ExportProducts streams.
+const exportChunkSize = 32 * 1024
+
+// ImportProducts reads the file from the chunks of the request stream. The
+// format and dry_run of the first message apply to the whole file.
+func (s *InventoryService) ImportProducts(stream grpc.ClientStreamingServer[v1.ImportProductsRequest, v1.ImportProductsResponse]) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "empty import stream")
+	}
+	if err != nil {
+		return err
+	}
+
+	file := &importStream{stream: stream, buf: first.Chunk}
+	result, err := s.importProducts(stream.Context(), file, fileFormat(first.Format), first.DryRun)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(result)
+}
+
+// ImportProductsHTTP handles POST /v1/products:import. The file is the
+// multipart part named "file"; format and dry_run come from the query or
+// from form fields sent before the file. Without a format the file
+// extension decides.
+func (s *InventoryService) ImportProductsHTTP(ctx http.Context) error {
+	mr, err := ctx.Request().MultipartReader()
+	if err != nil {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "expected a multipart/form-data body")
+	}
+
+	query := ctx.Request().URL.Query()
+	format := query.Get("format")
+	dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), `missing multipart field "file"`)
+		}
+		if err != nil {
+			return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), err.Error())
+		}
+
+		switch part.FormName() {
+		case "format":
+			format = readFormValue(part)
+		case "dry_run":
+			dryRun, _ = strconv.ParseBool(readFormValue(part))
+		case "file":
+			if format == "" {
+				format = productfile.FormatFromFilename(part.FileName())
+			}
+			http.SetOperation(ctx, v1.Inventory_ImportProducts_FullMethodName)
+			h := ctx.Middleware(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return s.importProducts(ctx, part, format, dryRun)
+			})
+			out, err := h(ctx, nil)
+			if err != nil {
+				return err
+			}
+			return ctx.Result(200, out)
+		}
+	}
+}
+
+func (s *InventoryService) importProducts(ctx context.Context, file io.Reader, format string, dryRun bool) (*v1.ImportProductsResponse, error) {
+	if format == "" {
+		format = productfile.FormatCSV
+	}
+	r, err := productfile.NewReader(file, format)
+	if err != nil {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), err.Error())
+	}
+
+	result, err := s.uc.ImportProducts(ctx, r, &dto.ImportProductsDTO{
+		DryRun: dryRun,
+		Actor:  actorFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return importResultToProto(result), nil
+}
+
+// ExportProducts streams the file in chunks of up to exportChunkSize bytes.
+func (s *InventoryService) ExportProducts(req *v1.ExportProductsRequest, stream grpc.ServerStreamingServer[v1.ExportProductsResponse]) error {
+	chunks := bufio.NewWriterSize(chunkWriter(func(p []byte) error {
+		return stream.Send(&v1.ExportProductsResponse{Chunk: p})
+	}), exportChunkSize)
+
+	if err := s.exportProducts(stream.Context(), chunks, fileFormat(req.Format), req.IncludeDeleted); err != nil {
+		return err
+	}
+	return chunks.Flush()
+}
+
+// ExportProductsHTTP handles GET /v1/products:export?format=csv|ndjson.
+func (s *InventoryService) ExportProductsHTTP(ctx http.Context) error {
+	query := ctx.Request().URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = productfile.FormatCSV
+	}
+	includeDeleted, _ := strconv.ParseBool(query.Get("include_deleted"))
+
+	var contentType string
+	switch format {
+	case productfile.FormatCSV:
+		contentType = "text/csv; charset=utf-8"
+	case productfile.FormatNDJSON:
+		contentType = "application/x-ndjson"
+	default:
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), productfile.ErrUnknownFormat.Error())
+	}
+	w := ctx.Response()
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="products.`+format+`"`)
+
+	http.SetOperation(ctx, v1.Inventory_ExportProducts_FullMethodName)
+	h := ctx.Middleware(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, s.exportProducts(ctx, w, format, includeDeleted)
+	})
+	_, err := h(ctx, nil)
+	return err
+}
+
+func (s *InventoryService) exportProducts(ctx context.Context, w io.Writer, format string, includeDeleted bool) error {
+	fw, err := productfile.NewWriter(w, format)
+	if err != nil {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), err.Error())
+	}
+	if err := s.uc.ExportProducts(ctx, &dto.ExportProductsQuery{IncludeDeleted: includeDeleted}, fw.Write); err != nil {
+		return err
+	}
+	return fw.Flush()
+}
+
+// importStream reads the chunks of an ImportProducts request stream as one file.
+type importStream struct {
+	stream grpc.ClientStreamingServer[v1.ImportProductsRequest, v1.ImportProductsResponse]
+	buf    []byte
+}
+
+func (r *importStream) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.Chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// chunkWriter sends each write as one chunk.
+type chunkWriter func([]byte) error
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	if err := w(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func fileFormat(f v1.FileFormat) string {
+	if f == v1.FileFormat_FILE_FORMAT_NDJSON {
+		return productfile.FormatNDJSON
+	}
+	return productfile.FormatCSV
+}
+
+// readFormValue reads a short multipart form field.
+func readFormValue(r io.Reader) string {
+	b, _ := io.ReadAll(io.LimitReader(r, 64))
+	return string(b)
+}
+
+func importResultToProto(result *dto.ImportResultDTO) *v1.ImportProductsResponse {
+	rowErrors := make([]*v1.ImportRowError, len(result.Errors))
+	for i, e := range result.Errors {
+		rowErrors[i] = &v1.ImportRowError{
+			Row:     e.Row,
+			Sku:     e.SKU,
+			Message: e.Message,
+		}
+	}
+	return &v1.ImportProductsResponse{
+		TotalRows: result.TotalRows,
+		Created:   result.Created,
+		Updated:   result.Updated,
+		Failed:    result.Failed,
+		DryRun:    result.DryRun,
+		Errors:    rowErrors,
+	}
+}
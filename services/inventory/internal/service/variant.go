@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/inventory/v1"
+	"github.com/reverny/kratos-mono/services/inventory/internal/dto"
+)
+
+func (s *InventoryService) CreateVariant(ctx context.Context, req *v1.CreateVariantRequest) (*v1.Product, error) {
+	createDTO := &dto.CreateProductDTO{
+		Name:        req.Name,
+		Description: req.Description,
+		SKU:         req.Sku,
+		Price:       priceToDTO(req.UnitPrice, 0),
+		Stock:       req.Stock,
+		Actor:       actorFromContext(ctx),
+		LocationID:  req.LocationId,
+		ParentID:    req.ProductId,
+		Attributes:  attributesToDTO(req.Attributes),
+	}
+
+	productDTO, err := s.uc.CreateVariant(ctx, createDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	return dtoToProto(productDTO), nil
+}
+
+func (s *InventoryService) ListVariants(ctx context.Context, req *v1.ListVariantsRequest) (*v1.ListVariantsResponse, error) {
+	variants, err := s.uc.ListVariants(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	protoVariants := make([]*v1.Product, len(variants))
+	for i, v := range variants {
+		protoVariants[i] = dtoToProto(v)
+	}
+
+	return &v1.ListVariantsResponse{
+		Variants: protoVariants,
+	}, nil
+}
+
+// attributeToDTO flattens the value oneof into its type and text form. An
+// attribute without a value keeps an empty type, which biz rejects.
+func attributeToDTO(a *v1.Attribute) *dto.AttributeDTO {
+	attribute := &dto.AttributeDTO{Name: a.Name}
+	switch v := a.Value.(type) {
+	case *v1.Attribute_StringValue:
+		attribute.Type, attribute.Value = dto.AttributeString, v.StringValue
+	case *v1.Attribute_IntValue:
+		attribute.Type, attribute.Value = dto.AttributeInt, strconv.FormatInt(v.IntValue, 10)
+	case *v1.Attribute_DoubleValue:
+		attribute.Type, attribute.Value = dto.AttributeDouble, strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+	case *v1.Attribute_BoolValue:
+		attribute.Type, attribute.Value = dto.AttributeBool, strconv.FormatBool(v.BoolValue)
+	}
+	return attribute
+}
+
+func attributeToProto(attribute *dto.AttributeDTO) *v1.Attribute {
+	a := &v1.Attribute{Name: attribute.Name}
+	switch attribute.Type {
+	case dto.AttributeString:
+		a.Value = &v1.Attribute_StringValue{StringValue: attribute.Value}
+	case dto.AttributeInt:
+		n, _ := strconv.ParseInt(attribute.Value, 10, 64)
+		a.Value = &v1.Attribute_IntValue{IntValue: n}
+	case dto.AttributeDouble:
+		f, _ := strconv.ParseFloat(attribute.Value, 64)
+		a.Value = &v1.Attribute_DoubleValue{DoubleValue: f}
+	case dto.AttributeBool:
+		b, _ := strconv.ParseBool(attribute.Value)
+		a.Value = &v1.Attribute_BoolValue{BoolValue: b}
+	}
+	return a
+}
+
+func attributesToDTO(attributes []*v1.Attribute) []*dto.AttributeDTO {
+	dtoAttributes := make([]*dto.AttributeDTO, len(attributes))
+	for i, a := range attributes {
+		dtoAttributes[i] = attributeToDTO(a)
+	}
+	return dtoAttributes
+}
+
+func attributesToProto(attributes []*dto.AttributeDTO) []*v1.Attribute {
+	protoAttributes := make([]*v1.Attribute, len(attributes))
+	for i, a := range attributes {
+		protoAttributes[i] = attributeToProto(a)
+	}
+	return protoAttributes
+}
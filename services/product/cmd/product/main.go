@@ -4,6 +4,7 @@ import (
 	"flag"
 	"os"
 
+	"github.com/reverny/kratos-mono/pkg/outbox"
 	"github.com/reverny/kratos-mono/services/product/internal/conf"
 
 	"github.com/go-kratos/kratos/v2"
@@ -29,7 +30,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, relay *outbox.Relay) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -39,6 +40,7 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			relay,
 		),
 	)
 }
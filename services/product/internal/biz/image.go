@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+)
+
+var (
+	// ErrImageNotFound is returned when an image reference names no file
+	// held by the filemanagement service.
+	ErrImageNotFound = errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "image is not a file uploaded to filemanagement")
+	// ErrImagesUnavailable is returned for image references when no
+	// filemanagement service is configured.
+	ErrImagesUnavailable = errors.ServiceUnavailable(common.ErrorCode_INTERNAL.String(), "product images are not available")
+)
+
+// Image is a product image stored by the filemanagement service.
+type Image struct {
+	FileID string
+	URL    string
+}
+
+// FileRepo looks up files held by the filemanagement service.
+type FileRepo interface {
+	// ResolveImage returns the file named by ref, either a file id or a URL
+	// of the file, or ErrImageNotFound.
+	ResolveImage(ctx context.Context, ref string) (*Image, error)
+}
+
+// resolveImage checks ref against filemanagement. An empty ref resolves to
+// an empty Image.
+func (uc *ProductUseCase) resolveImage(ctx context.Context, ref string) (*Image, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return &Image{}, nil
+	}
+	return uc.files.ResolveImage(ctx, ref)
+}
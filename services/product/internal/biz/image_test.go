@@ -0,0 +1,90 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// imageFiles resolves the refs in images and rejects any other.
+type imageFiles struct {
+	images   map[string]*Image
+	resolved []string
+}
+
+func (f *imageFiles) ResolveImage(_ context.Context, ref string) (*Image, error) {
+	f.resolved = append(f.resolved, ref)
+	image, ok := f.images[ref]
+	if !ok {
+		return nil, ErrImageNotFound
+	}
+	return image, nil
+}
+
+// savingRepo returns what it is asked to store.
+type savingRepo struct {
+	ProductRepo
+	saved *Product
+}
+
+func (r *savingRepo) Create(_ context.Context, p *Product) (*Product, error) {
+	r.saved = p
+	return p, nil
+}
+
+func (r *savingRepo) Update(_ context.Context, p *Product) (*Product, error) {
+	r.saved = p
+	return p, nil
+}
+
+func TestProductImages(t *testing.T) {
+	ctx := context.Background()
+	image := &Image{FileID: "f1", URL: "https://cdn.example.com/files/f1"}
+	ref := func(s string) *string { return &s }
+
+	cases := []struct {
+		name      string
+		create    bool
+		ref       *string
+		wantImage *Image // nil when the image is left out of the write
+		wantErr   error
+		resolved  bool
+	}{
+		{name: "create with a file", create: true, ref: ref("f1"), wantImage: image, resolved: true},
+		{name: "create with a padded ref", create: true, ref: ref("  f1 "), wantImage: image, resolved: true},
+		{name: "create without an image", create: true, ref: ref("   ")},
+		{name: "create with an unknown file", create: true, ref: ref("f2"), wantErr: ErrImageNotFound, resolved: true},
+		{name: "update keeps the image", ref: nil},
+		{name: "update removes the image", ref: ref(""), wantImage: &Image{}},
+		{name: "update replaces the image", ref: ref("f1"), wantImage: image, resolved: true},
+		{name: "update with an unknown file", ref: ref("f2"), wantErr: ErrImageNotFound, resolved: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			files := &imageFiles{images: map[string]*Image{"f1": image}}
+			repo := &savingRepo{}
+			uc := NewProductUseCase(repo, files, log.DefaultLogger)
+
+			var err error
+			if c.create {
+				_, err = uc.Create(ctx, &Product{Name: "Mug"}, *c.ref)
+			} else {
+				_, err = uc.Update(ctx, &Product{ID: 1, Name: "Mug"}, c.ref)
+			}
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) || repo.saved != nil {
+					t.Fatalf("got %v and saved %+v, want %v and nothing saved", err, repo.saved, c.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if got := repo.saved.Image; (got == nil) != (c.wantImage == nil) || got != nil && *got != *c.wantImage {
+				t.Fatalf("saved image %+v, want %+v", got, c.wantImage)
+			}
+			if resolved := len(files.resolved) > 0; resolved != c.resolved {
+				t.Fatalf("resolved %v", files.resolved)
+			}
+		})
+	}
+}
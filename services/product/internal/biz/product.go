@@ -2,37 +2,76 @@ package biz
 
 import (
 	"context"
+	"strings"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/pkg/errcode"
+)
+
+var (
+	// ErrProductNotFound is returned when no product exists for the given id or SKU.
+	ErrProductNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "product not found")
+	// ErrSKUAlreadyExists is returned when another product already uses the SKU.
+	ErrSKUAlreadyExists = errcode.AlreadyExists("sku already exists")
 )
 
+// Product is a catalog entry. Its ID is the product identity other services,
+// such as inventory, refer to.
 type Product struct {
 	ID   int64
 	Name string
+	SKU  string
+	// Image is nil when the product has none. Passed to Update, nil keeps
+	// the stored image and an empty Image removes it.
+	Image *Image
+}
+
+// ListQuery selects a page of products. A non-empty PageToken takes
+// precedence over Page.
+type ListQuery struct {
+	Page      int
+	PageSize  int
+	PageToken string
 }
 
 type ProductRepo interface {
 	Create(context.Context, *Product) (*Product, error)
 	Get(context.Context, int64) (*Product, error)
-	List(context.Context, int, int) ([]*Product, int, error)
+	GetBySku(context.Context, string) (*Product, error)
+	List(context.Context, *ListQuery) ([]*Product, int, string, error)
 	Update(context.Context, *Product) (*Product, error)
 	Delete(context.Context, int64) error
 }
 
 type ProductUseCase struct {
-	repo ProductRepo
-	log  *log.Helper
+	repo  ProductRepo
+	files FileRepo
+	log   *log.Helper
 }
 
-func NewProductUseCase(repo ProductRepo, logger log.Logger) *ProductUseCase {
+func NewProductUseCase(repo ProductRepo, files FileRepo, logger log.Logger) *ProductUseCase {
 	return &ProductUseCase{
-		repo: repo,
-		log:  log.NewHelper(logger),
+		repo:  repo,
+		files: files,
+		log:   log.NewHelper(logger),
 	}
 }
 
-func (uc *ProductUseCase) Create(ctx context.Context, item *Product) (*Product, error) {
+// Create creates a product. imageRef names its image by filemanagement file
+// id or URL; empty creates it without one.
+func (uc *ProductUseCase) Create(ctx context.Context, item *Product, imageRef string) (*Product, error) {
 	uc.log.WithContext(ctx).Infof("CreateProduct: %v", item.Name)
+	item.SKU = strings.TrimSpace(item.SKU)
+	if strings.TrimSpace(imageRef) != "" {
+		image, err := uc.resolveImage(ctx, imageRef)
+		if err != nil {
+			return nil, err
+		}
+		item.Image = image
+	}
 	return uc.repo.Create(ctx, item)
 }
 
@@ -41,13 +80,44 @@ func (uc *ProductUseCase) Get(ctx context.Context, id int64) (*Product, error) {
 	return uc.repo.Get(ctx, id)
 }
 
-func (uc *ProductUseCase) List(ctx context.Context, page, pageSize int) ([]*Product, int, error) {
-	uc.log.WithContext(ctx).Infof("ListProduct: page=%d, pageSize=%d", page, pageSize)
-	return uc.repo.List(ctx, page, pageSize)
+func (uc *ProductUseCase) GetBySku(ctx context.Context, sku string) (*Product, error) {
+	uc.log.WithContext(ctx).Infof("GetProductBySku: %s", sku)
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "sku is required")
+	}
+	return uc.repo.GetBySku(ctx, sku)
 }
 
-func (uc *ProductUseCase) Update(ctx context.Context, item *Product) (*Product, error) {
+// List returns a page of products, the total (offset paging only) and the
+// token of the next page.
+func (uc *ProductUseCase) List(ctx context.Context, query *ListQuery) ([]*Product, int, string, error) {
+	uc.log.WithContext(ctx).Infof("ListProduct: page=%d, pageSize=%d", query.Page, query.PageSize)
+	if query.PageToken != "" {
+		query.Page = 0
+	} else if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 10
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100
+	}
+	return uc.repo.List(ctx, query)
+}
+
+// Update renames a product. A nil imageRef keeps its image; an empty one
+// removes it.
+func (uc *ProductUseCase) Update(ctx context.Context, item *Product, imageRef *string) (*Product, error) {
 	uc.log.WithContext(ctx).Infof("UpdateProduct: %v", item)
+	if imageRef != nil {
+		image, err := uc.resolveImage(ctx, *imageRef)
+		if err != nil {
+			return nil, err
+		}
+		item.Image = image
+	}
 	return uc.repo.Update(ctx, item)
 }
 
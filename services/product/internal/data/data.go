@@ -1,6 +1,12 @@
 package data
 
 import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/sqldb"
 	"github.com/reverny/kratos-mono/services/product/internal/biz"
 	"github.com/reverny/kratos-mono/services/product/internal/conf"
 
@@ -8,19 +14,49 @@ import (
 	"github.com/google/wire"
 )
 
-var ProviderSet = wire.NewSet(NewData, NewProductRepo)
+var ProviderSet = wire.NewSet(
+	NewData,
+	NewProductRepo,
+	NewFileRepo,
+	outbox.NewChannelPublisher,
+	NewOutboxRelay,
+)
+
+// migrationFS holds the versioned schema migrations applied by NewData.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
 
 type Data struct {
+	*sqldb.DB
 	log *log.Helper
 }
 
 func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
+	helper := log.NewHelper(logger)
+
+	ctx := context.Background()
+	db, err := sqldb.Open(ctx, c.GetDatabase().GetDriver(), c.GetDatabase().GetSource())
+	if err != nil {
+		return nil, nil, err
+	}
+	migrations, err := fs.Sub(migrationFS, "migrations")
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	if err := db.Migrate(ctx, migrations, helper); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
 	cleanup := func() {
-		log.NewHelper(logger).Info("closing the data resources")
+		helper.Info("closing the data resources")
+		if err := db.Close(); err != nil {
+			helper.Error(err)
+		}
 	}
-	return &Data{
-		log: log.NewHelper(logger),
-	}, cleanup, nil
+	return &Data{DB: db, log: helper}, cleanup, nil
 }
 
 type productRepo struct {
@@ -0,0 +1,25 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/product/internal/conf"
+)
+
+// newTestData opens a migrated SQLite database that lives as long as t.
+func newTestData(t *testing.T) *Data {
+	t.Helper()
+	c := &conf.Data{Database: &conf.Data_Database{
+		Driver: "sqlite",
+		Source: "file:" + filepath.Join(t.TempDir(), "product.db"),
+	}}
+	d, cleanup, err := NewData(c, log.DefaultLogger)
+	if err != nil {
+		t.Fatalf("NewData: %v", err)
+	}
+	t.Cleanup(cleanup)
+	return d
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/product/internal/biz"
+	"github.com/reverny/kratos-mono/services/product/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+
+	filev1 "github.com/reverny/kratos-mono/gen/go/api/filemanagement/v1"
+)
+
+// fileIDPattern matches the ids issued by filemanagement. Anything else is
+// rejected before it reaches the storage backend.
+var fileIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+type fileRepo struct {
+	client  filev1.FilemanagementClient
+	baseURL *url.URL // nil when image URLs are not accepted
+	log     *log.Helper
+}
+
+// NewFileRepo connects to the filemanagement service at c.Files.Endpoint.
+// Without an endpoint every image reference is rejected.
+func NewFileRepo(c *conf.Data, logger log.Logger) (biz.FileRepo, func(), error) {
+	helper := log.NewHelper(logger)
+
+	endpoint := c.GetFiles().GetEndpoint()
+	if endpoint == "" {
+		helper.Warn("filemanagement endpoint not configured; product images are disabled")
+		return noFiles{}, func() {}, nil
+	}
+
+	var baseURL *url.URL
+	if raw := c.GetFiles().GetBaseUrl(); raw != "" {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return nil, nil, fmt.Errorf("invalid files base_url %q", raw)
+		}
+		baseURL = u
+	}
+
+	opts := []grpc.ClientOption{
+		grpc.WithEndpoint(endpoint),
+		// Calls are made on behalf of the user being served.
+		grpc.WithMiddleware(auth.Client()),
+	}
+	if timeout := c.GetFiles().GetTimeout(); timeout != nil {
+		opts = append(opts, grpc.WithTimeout(timeout.AsDuration()))
+	}
+	conn, err := grpc.DialInsecure(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dial filemanagement %s: %w", endpoint, err)
+	}
+
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			helper.Error(err)
+		}
+	}
+	return &fileRepo{
+		client:  filev1.NewFilemanagementClient(conn),
+		baseURL: baseURL,
+		log:     helper,
+	}, cleanup, nil
+}
+
+func (r *fileRepo) ResolveImage(ctx context.Context, ref string) (*biz.Image, error) {
+	fileID := ref
+	if strings.Contains(ref, "://") {
+		id, ok := r.fileIDFromURL(ref)
+		if !ok {
+			return nil, biz.ErrImageNotFound
+		}
+		fileID = id
+	}
+	if !fileIDPattern.MatchString(fileID) {
+		return nil, biz.ErrImageNotFound
+	}
+
+	info, err := r.client.GetFileInfo(ctx, &filev1.GetFileInfoRequest{FileId: fileID})
+	if errors.IsNotFound(err) {
+		return nil, biz.ErrImageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get file %s: %w", fileID, err)
+	}
+	return &biz.Image{FileID: info.GetFileId(), URL: info.GetFileUrl()}, nil
+}
+
+// fileIDFromURL extracts the file id from a file or download URL under the
+// configured base URL: "<base>/<file id>[/<file name>]".
+func (r *fileRepo) fileIDFromURL(raw string) (string, bool) {
+	if r.baseURL == nil {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != r.baseURL.Scheme || !strings.EqualFold(u.Host, r.baseURL.Host) {
+		return "", false
+	}
+	rest, ok := strings.CutPrefix(u.Path, strings.TrimSuffix(r.baseURL.Path, "/")+"/")
+	if !ok {
+		return "", false
+	}
+	fileID, _, _ := strings.Cut(rest, "/")
+	return fileID, fileID != ""
+}
+
+// noFiles stands in when no filemanagement service is configured.
+type noFiles struct{}
+
+func (noFiles) ResolveImage(context.Context, string) (*biz.Image, error) {
+	return nil, biz.ErrImagesUnavailable
+}
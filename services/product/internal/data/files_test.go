@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	filev1 "github.com/reverny/kratos-mono/gen/go/api/filemanagement/v1"
+	"github.com/reverny/kratos-mono/services/product/internal/biz"
+	"github.com/reverny/kratos-mono/services/product/internal/conf"
+)
+
+// fakeFiles is a filemanagement service holding the files in infos. It
+// records the ids it was asked for, and fails every lookup with err when set.
+type fakeFiles struct {
+	filev1.FilemanagementClient
+	infos     map[string]*filev1.GetFileInfoReply
+	err       error
+	requested []string
+}
+
+func (f *fakeFiles) GetFileInfo(_ context.Context, in *filev1.GetFileInfoRequest, _ ...grpc.CallOption) (*filev1.GetFileInfoReply, error) {
+	f.requested = append(f.requested, in.FileId)
+	if f.err != nil {
+		return nil, f.err
+	}
+	info, ok := f.infos[in.FileId]
+	if !ok {
+		return nil, errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "file not found")
+	}
+	return info, nil
+}
+
+func TestResolveImage(t *testing.T) {
+	ctx := context.Background()
+	baseURL, _ := url.Parse("https://cdn.example.com/files")
+	unavailable := errors.ServiceUnavailable(common.ErrorCode_INTERNAL.String(), "unavailable")
+
+	cases := []struct {
+		name    string
+		ref     string
+		baseURL *url.URL
+		err     error // returned by the fake service
+		want    string
+		wantErr error // nil when the error is not a biz error
+		asked   bool  // the service was asked for the file
+	}{
+		{name: "file id", ref: "f1", baseURL: baseURL, want: "f1", asked: true},
+		{name: "file URL", ref: "https://cdn.example.com/files/f1", baseURL: baseURL, want: "f1", asked: true},
+		{name: "download URL", ref: "https://CDN.example.com/files/f1/mug.png", baseURL: baseURL, want: "f1", asked: true},
+		{name: "unknown file", ref: "f2", baseURL: baseURL, wantErr: biz.ErrImageNotFound, asked: true},
+		{name: "invalid file id", ref: "../f1", baseURL: baseURL, wantErr: biz.ErrImageNotFound},
+		{name: "other host", ref: "https://evil.example.com/files/f1", baseURL: baseURL, wantErr: biz.ErrImageNotFound},
+		{name: "other scheme", ref: "http://cdn.example.com/files/f1", baseURL: baseURL, wantErr: biz.ErrImageNotFound},
+		{name: "outside the base path", ref: "https://cdn.example.com/filesx/f1", baseURL: baseURL, wantErr: biz.ErrImageNotFound},
+		{name: "no file id in the URL", ref: "https://cdn.example.com/files/", baseURL: baseURL, wantErr: biz.ErrImageNotFound},
+		{name: "URL without base_url", ref: "https://cdn.example.com/files/f1", wantErr: biz.ErrImageNotFound},
+		{name: "service failure", ref: "f1", baseURL: baseURL, err: unavailable, asked: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			files := &fakeFiles{
+				infos: map[string]*filev1.GetFileInfoReply{"f1": {FileId: "f1", FileUrl: "https://cdn.example.com/files/f1"}},
+				err:   c.err,
+			}
+			repo := &fileRepo{client: files, baseURL: c.baseURL, log: log.NewHelper(log.DefaultLogger)}
+
+			image, err := repo.ResolveImage(ctx, c.ref)
+			switch {
+			case c.want != "":
+				if err != nil || image.FileID != c.want || image.URL == "" {
+					t.Fatalf("got %+v, %v; want file %s", image, err, c.want)
+				}
+			case c.wantErr != nil:
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got %v, want %v", err, c.wantErr)
+				}
+			default:
+				if err == nil || errors.Is(err, biz.ErrImageNotFound) {
+					t.Fatalf("got %v, want the service error", err)
+				}
+			}
+			if asked := len(files.requested) > 0; asked != c.asked {
+				t.Fatalf("asked filemanagement for %v", files.requested)
+			}
+		})
+	}
+}
+
+func TestNewFileRepo(t *testing.T) {
+	repo, cleanup, err := NewFileRepo(&conf.Data{}, log.DefaultLogger)
+	if err != nil {
+		t.Fatalf("NewFileRepo without an endpoint: %v", err)
+	}
+	defer cleanup()
+	if _, err := repo.ResolveImage(context.Background(), "f1"); !errors.Is(err, biz.ErrImagesUnavailable) {
+		t.Fatalf("ResolveImage without an endpoint: got %v, want ErrImagesUnavailable", err)
+	}
+
+	for _, raw := range []string{"cdn.example.com/files", "://bad"} {
+		c := &conf.Data{Files: &conf.Data_Files{Endpoint: "127.0.0.1:9000", BaseUrl: raw}}
+		if _, _, err := NewFileRepo(c, log.DefaultLogger); err == nil {
+			t.Errorf("NewFileRepo accepted base_url %q", raw)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+-- Catalog products. The id is the product identity other services refer to.
+-- sku is NULL for products created without one, so that the unique index
+-- only applies to products that have a SKU.
+CREATE TABLE products (
+    id BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    sku VARCHAR(64) NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX uq_products_sku ON products (sku);
@@ -0,0 +1,5 @@
+-- Product images are files held by the filemanagement service. Both columns
+-- are empty for products without an image.
+ALTER TABLE products ADD COLUMN image_file_id VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE products ADD COLUMN image_url VARCHAR(1024) NOT NULL DEFAULT '';
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/outbox/outboxkafka"
+	"github.com/reverny/kratos-mono/pkg/outbox/outboxnats"
+	"github.com/reverny/kratos-mono/services/product/internal/biz"
+	"github.com/reverny/kratos-mono/services/product/internal/conf"
+)
+
+// eventSource names this service in the events it records.
+const eventSource = "product"
+
+// Supported values for conf.Data.Outbox.Publisher.
+const (
+	publisherChannel = "channel"
+	publisherNATS    = "nats"
+	publisherKafka   = "kafka"
+)
+
+// NewOutboxRelay builds the relay that delivers recorded events to the
+// publisher chosen by c.Outbox. The channel publisher hands them to
+// subscribers of events and holds them back while there are none.
+func NewOutboxRelay(c *conf.Data, d *Data, events *outbox.ChannelPublisher, logger log.Logger) (*outbox.Relay, func(), error) {
+	oc := c.GetOutbox()
+
+	var (
+		publisher outbox.Publisher
+		cleanup   = func() {}
+	)
+	switch oc.GetPublisher() {
+	case "", publisherChannel:
+		publisher = events
+	case publisherNATS:
+		p, closeConn, err := outboxnats.Connect(oc.GetNatsUrl(), oc.GetNatsSubjectPrefix())
+		if err != nil {
+			return nil, nil, err
+		}
+		publisher, cleanup = p, closeConn
+	case publisherKafka:
+		w := outboxkafka.NewWriter(oc.GetKafkaBrokers(), oc.GetKafkaTopic())
+		publisher = outboxkafka.NewPublisher(w)
+		cleanup = func() {
+			if err := w.Close(); err != nil {
+				log.NewHelper(logger).Error(err)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported outbox publisher: %q", oc.GetPublisher())
+	}
+
+	relay := outbox.NewRelay(outbox.NewSQLStore(d.SQL()), publisher,
+		oc.GetRelayInterval().AsDuration(), int(oc.GetBatchSize()), logger)
+	return relay, cleanup, nil
+}
+
+// productEvent is the payload of the Product* events.
+type productEvent struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SKU         string `json:"sku,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	ImageFileID string `json:"image_file_id,omitempty"`
+}
+
+// recordProductEvent appends a Product* event for item to the outbox in the
+// transaction bound to ctx.
+func recordProductEvent(ctx context.Context, d *Data, eventType string, item *biz.Product) error {
+	payload := &productEvent{ID: item.ID, Name: item.Name, SKU: item.SKU}
+	if item.Image != nil {
+		payload.ImageURL, payload.ImageFileID = item.Image.URL, item.Image.FileID
+	}
+	event, err := outbox.NewEvent(eventSource, eventType, strconv.FormatInt(item.ID, 10), payload)
+	if err != nil {
+		return err
+	}
+	return outbox.Append(ctx, d.Conn(ctx), event)
+}
@@ -2,39 +2,210 @@ package data
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/reverny/kratos-mono/pkg/outbox"
+	"github.com/reverny/kratos-mono/pkg/pagination"
+	"github.com/reverny/kratos-mono/pkg/sqldb"
 	"github.com/reverny/kratos-mono/services/product/internal/biz"
 )
 
+const productColumns = `id, name, sku, image_file_id, image_url`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row rowScanner) (*biz.Product, error) {
+	var (
+		item  biz.Product
+		sku   sql.NullString
+		image biz.Image
+	)
+	if err := row.Scan(&item.ID, &item.Name, &sku, &image.FileID, &image.URL); err != nil {
+		return nil, err
+	}
+	item.SKU = sku.String
+	if image.FileID != "" {
+		item.Image = &image
+	}
+	return &item, nil
+}
+
+// nullSKU stores an empty SKU as NULL, which the unique index ignores.
+func nullSKU(sku string) sql.NullString {
+	return sql.NullString{String: sku, Valid: sku != ""}
+}
+
+// nowUTC returns the current time at the precision stored by every supported driver.
+func nowUTC() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
 func (r *productRepo) Create(ctx context.Context, item *biz.Product) (*biz.Product, error) {
-	// TODO: implement database create
-	item.ID = 1
-	return item, nil
+	var image biz.Image
+	if item.Image != nil {
+		image = *item.Image
+	}
+	var created *biz.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		now := nowUTC()
+		res, err := r.data.Conn(ctx).ExecContext(ctx,
+			`INSERT INTO products (name, sku, image_file_id, image_url, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			item.Name, nullSKU(item.SKU), image.FileID, image.URL, now, now,
+		)
+		if sqldb.IsDuplicateKey(err) {
+			return biz.ErrSKUAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("create product: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("create product: %w", err)
+		}
+		if created, err = r.Get(ctx, id); err != nil {
+			return err
+		}
+		return recordProductEvent(ctx, r.data, outbox.TypeProductCreated, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Product created: %d", created.ID)
+	return created, nil
 }
 
 func (r *productRepo) Get(ctx context.Context, id int64) (*biz.Product, error) {
-	// TODO: implement database get
-	return &biz.Product{
-		ID:   id,
-		Name: "sample",
-	}, nil
+	item, err := scanProduct(r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT `+productColumns+` FROM products WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product %d: %w", id, err)
+	}
+	return item, nil
 }
 
-func (r *productRepo) List(ctx context.Context, page, pageSize int) ([]*biz.Product, int, error) {
-	// TODO: implement database list
-	items := []*biz.Product{
-		{ID: 1, Name: "sample1"},
-		{ID: 2, Name: "sample2"},
+func (r *productRepo) GetBySku(ctx context.Context, sku string) (*biz.Product, error) {
+	item, err := scanProduct(r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT `+productColumns+` FROM products WHERE sku = ?`, sku))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product by sku %s: %w", sku, err)
 	}
-	return items, len(items), nil
+	return item, nil
+}
+
+func (r *productRepo) List(ctx context.Context, query *biz.ListQuery) ([]*biz.Product, int, string, error) {
+	// Keyset by id: a page token carries the last id returned. Offset
+	// paging also counts the total.
+	var (
+		where string
+		args  []any
+		total int
+	)
+	if query.PageToken != "" {
+		cursor, err := pagination.Decode(query.PageToken, "")
+		if err != nil {
+			return nil, 0, "", err
+		}
+		lastID, err := strconv.ParseInt(cursor.ID, 10, 64)
+		if err != nil {
+			return nil, 0, "", pagination.ErrInvalidPageToken
+		}
+		where, args = ` WHERE id > ?`, append(args, lastID)
+	} else {
+		if err := r.data.Conn(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM products`).Scan(&total); err != nil {
+			return nil, 0, "", fmt.Errorf("count products: %w", err)
+		}
+	}
+
+	// Fetch one extra row to learn whether another page follows.
+	sqlQuery := `SELECT ` + productColumns + ` FROM products` + where + ` ORDER BY id LIMIT ?`
+	args = append(args, query.PageSize+1)
+	if query.PageToken == "" {
+		sqlQuery += ` OFFSET ?`
+		args = append(args, (query.Page-1)*query.PageSize)
+	}
+	rows, err := r.data.Conn(ctx).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("list products: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*biz.Product, 0, query.PageSize)
+	for rows.Next() {
+		item, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("scan product: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, "", fmt.Errorf("list products: %w", err)
+	}
+
+	if len(items) <= query.PageSize {
+		return items, total, "", nil
+	}
+	items = items[:query.PageSize]
+	next := pagination.Encode(&pagination.Cursor{ID: strconv.FormatInt(items[len(items)-1].ID, 10)})
+	return items, total, next, nil
 }
 
 func (r *productRepo) Update(ctx context.Context, item *biz.Product) (*biz.Product, error) {
-	// TODO: implement database update
-	return item, nil
+	// MySQL reports rows left unchanged as unaffected, so a missing id is
+	// detected by the read that follows rather than by RowsAffected.
+	query := `UPDATE products SET name = ?, updated_at = ?`
+	args := []any{item.Name, nowUTC()}
+	if item.Image != nil {
+		query += `, image_file_id = ?, image_url = ?`
+		args = append(args, item.Image.FileID, item.Image.URL)
+	}
+	var updated *biz.Product
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		_, err := r.data.Conn(ctx).ExecContext(ctx, query+` WHERE id = ?`, append(args, item.ID)...)
+		if err != nil {
+			return fmt.Errorf("update product %d: %w", item.ID, err)
+		}
+		if updated, err = r.Get(ctx, item.ID); err != nil {
+			return err
+		}
+		return recordProductEvent(ctx, r.data, outbox.TypeProductUpdated, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Product updated: %d", item.ID)
+	return updated, nil
 }
 
 func (r *productRepo) Delete(ctx context.Context, id int64) error {
-	// TODO: implement database delete
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		item, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := r.data.Conn(ctx).ExecContext(ctx, `DELETE FROM products WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("delete product %d: %w", id, err)
+		}
+		return recordProductEvent(ctx, r.data, outbox.TypeProductDeleted, item)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.log.Infof("Product deleted: %d", id)
 	return nil
 }
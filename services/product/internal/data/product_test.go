@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/product/internal/biz"
+)
+
+func TestProductLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepo(newTestData(t), log.DefaultLogger)
+
+	created, err := repo.Create(ctx, &biz.Product{Name: "Mug", SKU: "MUG-1"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.Create(ctx, &biz.Product{Name: "Other mug", SKU: "MUG-1"}); !errors.Is(err, biz.ErrSKUAlreadyExists) {
+		t.Fatalf("Create with a used SKU: got %v, want ErrSKUAlreadyExists", err)
+	}
+	// Products without a SKU do not collide.
+	for i := 0; i < 2; i++ {
+		if _, err := repo.Create(ctx, &biz.Product{Name: "Unlabelled"}); err != nil {
+			t.Fatalf("Create without a SKU: %v", err)
+		}
+	}
+
+	got, err := repo.GetBySku(ctx, "MUG-1")
+	if err != nil {
+		t.Fatalf("GetBySku: %v", err)
+	}
+	if got.ID != created.ID || got.Name != "Mug" || got.Image != nil {
+		t.Fatalf("GetBySku returned %+v", got)
+	}
+
+	updated, err := repo.Update(ctx, &biz.Product{ID: created.ID, Name: "Large mug", Image: &biz.Image{FileID: "f1", URL: "https://cdn/f1"}})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Name != "Large mug" || updated.SKU != "MUG-1" || updated.Image == nil || updated.Image.FileID != "f1" {
+		t.Fatalf("Update returned %+v", updated)
+	}
+	// A nil image keeps the stored one.
+	if updated, err = repo.Update(ctx, &biz.Product{ID: created.ID, Name: "Mug"}); err != nil || updated.Image == nil {
+		t.Fatalf("Update without an image: %+v, %v", updated, err)
+	}
+	if _, err := repo.Update(ctx, &biz.Product{ID: created.ID + 100, Name: "Ghost"}); !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("Update of a missing product: got %v, want ErrProductNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.Get(ctx, created.ID); !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("Get after delete: got %v, want ErrProductNotFound", err)
+	}
+	if err := repo.Delete(ctx, created.ID); !errors.Is(err, biz.ErrProductNotFound) {
+		t.Fatalf("Delete twice: got %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestListPages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepo(newTestData(t), log.DefaultLogger)
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		p, err := repo.Create(ctx, &biz.Product{Name: fmt.Sprintf("Product %d", i), SKU: fmt.Sprintf("SKU-%d", i)})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		ids = append(ids, p.ID)
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		for page, want := range []int{2, 2, 1} {
+			items, total, _, err := repo.List(ctx, &biz.ListQuery{Page: page + 1, PageSize: 2})
+			if err != nil {
+				t.Fatalf("page %d: %v", page+1, err)
+			}
+			if len(items) != want || total != 5 {
+				t.Fatalf("page %d: got %d products of %d, want %d of 5", page+1, len(items), total, want)
+			}
+			if items[0].ID != ids[page*2] {
+				t.Fatalf("page %d starts at %d, want %d", page+1, items[0].ID, ids[page*2])
+			}
+		}
+	})
+
+	t.Run("token", func(t *testing.T) {
+		query := &biz.ListQuery{PageSize: 2}
+		var got []int64
+		for {
+			items, _, next, err := repo.List(ctx, query)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			for _, item := range items {
+				got = append(got, item.ID)
+			}
+			if next == "" {
+				break
+			}
+			query.PageToken = next
+		}
+		if fmt.Sprint(got) != fmt.Sprint(ids) {
+			t.Fatalf("listed %v, want %v", got, ids)
+		}
+	})
+}
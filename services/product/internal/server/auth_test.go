@@ -0,0 +1,23 @@
+package server
+
+import (
+	"testing"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/auth/authztest"
+)
+
+func TestPolicy(t *testing.T) {
+	authztest.Run(t, policy, []authztest.Case{
+		{Operation: v1.Product_CreateProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Product_CreateProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Product_GetProduct_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Product_GetProductBySku_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Product_ListProduct_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Product_UpdateProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Product_UpdateProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.Product_DeleteProduct_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.Product_DeleteProduct_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+	})
+}
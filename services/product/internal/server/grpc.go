@@ -2,6 +2,7 @@ package server
 
 import (
 	v1 "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
 	"github.com/reverny/kratos-mono/services/product/internal/conf"
 	"github.com/reverny/kratos-mono/services/product/internal/service"
 
@@ -10,10 +11,11 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
-func NewGRPCServer(c *conf.Server, productSvc *service.ProductService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, verifier *auth.Verifier, productSvc *service.ProductService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			auth.Middleware(verifier, policy),
 		),
 	}
 	if c.Grpc.Network != "" {
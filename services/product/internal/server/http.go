@@ -5,6 +5,7 @@ import (
 	nethttp "net/http"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
 	"github.com/reverny/kratos-mono/services/product/internal/conf"
 	"github.com/reverny/kratos-mono/services/product/internal/service"
 
@@ -16,10 +17,11 @@ import (
 //go:embed swagger.html
 var swaggerHTML []byte
 
-func NewHTTPServer(c *conf.Server, productSvc *service.ProductService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, verifier *auth.Verifier, productSvc *service.ProductService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(),
+			auth.Middleware(verifier, policy),
 		),
 	}
 	if c.Http.Network != "" {
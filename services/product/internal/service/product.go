@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/reverny/kratos-mono/gen/go/api/product/v1"
+	"github.com/reverny/kratos-mono/pkg/pagination"
 	"github.com/reverny/kratos-mono/services/product/internal/biz"
 )
 
@@ -18,15 +19,12 @@ func NewProductService(uc *biz.ProductUseCase) *ProductService {
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductReply, error) {
-	item, err := s.uc.Create(ctx, &biz.Product{Name: req.Name})
+	item, err := s.uc.Create(ctx, &biz.Product{Name: req.Name, SKU: req.Sku}, req.Url)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.CreateProductReply{
-		Data: &pb.ProductItem{
-			Id:   item.ID,
-			Name: item.Name,
-		},
+		Data: productToProto(item),
 	}, nil
 }
 
@@ -36,30 +34,40 @@ func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetProductReque
 		return nil, err
 	}
 	return &pb.GetProductReply{
-		Data: &pb.ProductItem{
-			Id:   item.ID,
-			Name: item.Name,
-		},
+		Data: productToProto(item),
+	}, nil
+}
+
+func (s *ProductService) GetProductBySku(ctx context.Context, req *pb.GetProductBySkuRequest) (*pb.GetProductBySkuReply, error) {
+	item, err := s.uc.GetBySku(ctx, req.Sku)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetProductBySkuReply{
+		Data: productToProto(item),
 	}, nil
 }
 
 func (s *ProductService) ListProduct(ctx context.Context, req *pb.ListProductRequest) (*pb.ListProductReply, error) {
-	items, total, err := s.uc.List(ctx, int(req.Page), int(req.PageSize))
+	query := &biz.ListQuery{
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	items, total, next, err := s.uc.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	
 	pbItems := make([]*pb.ProductItem, len(items))
 	for i, item := range items {
-		pbItems[i] = &pb.ProductItem{
-			Id:   item.ID,
-			Name: item.Name,
-		}
+		pbItems[i] = productToProto(item)
 	}
 	
 	return &pb.ListProductReply{
-		Items: pbItems,
-		Total: int32(total),
+		Items:      pbItems,
+		Total:      int32(total),
+		Pagination: pagination.Proto(int32(query.Page), int32(query.PageSize), int32(total), next),
 	}, nil
 }
 
@@ -67,15 +75,12 @@ func (s *ProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProduc
 	item, err := s.uc.Update(ctx, &biz.Product{
 		ID:   req.Id,
 		Name: req.Name,
-	})
+	}, req.Url)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.UpdateProductReply{
-		Data: &pb.ProductItem{
-			Id:   item.ID,
-			Name: item.Name,
-		},
+		Data: productToProto(item),
 	}, nil
 }
 
@@ -86,3 +91,16 @@ func (s *ProductService) DeleteProduct(ctx context.Context, req *pb.DeleteProduc
 	}
 	return &pb.DeleteProductReply{Success: true}, nil
 }
+
+func productToProto(item *biz.Product) *pb.ProductItem {
+	p := &pb.ProductItem{
+		Id:   item.ID,
+		Name: item.Name,
+		Sku:  item.SKU,
+	}
+	if item.Image != nil {
+		p.ImageUrl = item.Image.URL
+		p.ImageFileId = item.Image.FileID
+	}
+	return p
+}
@@ -11,7 +11,7 @@ import (
 type TestRepo interface {
 	Create(context.Context, *dto.CreateTestDTO) (*dto.TestDTO, error)
 	Get(context.Context, int64) (*dto.TestDTO, error)
-	List(context.Context, *dto.ListTestQuery) ([]*dto.TestDTO, int, error)
+	List(context.Context, *dto.ListTestQuery) ([]*dto.TestDTO, *dto.PageDTO, error)
 	Update(context.Context, *dto.UpdateTestDTO) (*dto.TestDTO, error)
 	Delete(context.Context, int64) error
 }
@@ -38,8 +38,19 @@ func (uc *TestUseCase) Get(ctx context.Context, id int64) (*dto.TestDTO, error)
 	return uc.repo.Get(ctx, id)
 }
 
-func (uc *TestUseCase) List(ctx context.Context, query *dto.ListTestQuery) ([]*dto.TestDTO, int, error) {
+func (uc *TestUseCase) List(ctx context.Context, query *dto.ListTestQuery) ([]*dto.TestDTO, *dto.PageDTO, error) {
 	uc.log.WithContext(ctx).Infof("ListTest: page=%d, pageSize=%d", query.Page, query.PageSize)
+	if query.PageToken != "" {
+		query.Page = 0
+	} else if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 10
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100
+	}
 	return uc.repo.List(ctx, query)
 }
 
@@ -2,7 +2,9 @@ package data
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/reverny/kratos-mono/pkg/pagination"
 	"github.com/reverny/kratos-mono/services/test/internal/data/entity"
 	"github.com/reverny/kratos-mono/services/test/internal/dto"
 )
@@ -29,19 +31,49 @@ func (r *testRepo) Get(ctx context.Context, id int64) (*dto.TestDTO, error) {
 	return ent.ToDTO(), nil
 }
 
-func (r *testRepo) List(ctx context.Context, query *dto.ListTestQuery) ([]*dto.TestDTO, int, error) {
+func (r *testRepo) List(ctx context.Context, query *dto.ListTestQuery) ([]*dto.TestDTO, *dto.PageDTO, error) {
 	// TODO: implement database list with pagination
 	entities := []*entity.Test{
 		{ID: 1, Name: "sample1"},
 		{ID: 2, Name: "sample2"},
 	}
+
+	// Keyset by id: a page token carries the last id returned.
+	page := &dto.PageDTO{Total: len(entities)}
+	start := int((query.Page - 1) * query.PageSize)
+	if query.PageToken != "" {
+		cursor, err := pagination.Decode(query.PageToken, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		lastID, err := strconv.ParseInt(cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, pagination.ErrInvalidPageToken
+		}
+		page.Total, start = 0, len(entities)
+		for i, ent := range entities {
+			if ent.ID > lastID {
+				start = i
+				break
+			}
+		}
+	}
+	if start > len(entities) {
+		start = len(entities)
+	}
+	end := start + int(query.PageSize)
+	if end < len(entities) {
+		page.NextPageToken = pagination.Encode(&pagination.Cursor{ID: strconv.FormatInt(entities[end-1].ID, 10)})
+	} else {
+		end = len(entities)
+	}
 	
-	dtos := make([]*dto.TestDTO, len(entities))
-	for i, ent := range entities {
-		dtos[i] = ent.ToDTO()
+	dtos := make([]*dto.TestDTO, 0, end-start)
+	for _, ent := range entities[start:end] {
+		dtos = append(dtos, ent.ToDTO())
 	}
 	
-	return dtos, len(dtos), nil
+	return dtos, page, nil
 }
 
 func (r *testRepo) Update(ctx context.Context, req *dto.UpdateTestDTO) (*dto.TestDTO, error) {
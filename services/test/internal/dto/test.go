@@ -19,6 +19,13 @@ type UpdateTestDTO struct {
 
 // ListTestQuery for list query parameters
 type ListTestQuery struct {
-	Page     int32
-	PageSize int32
+	Page      int32
+	PageSize  int32
+	PageToken string // continues from a previous page; takes precedence over Page
+}
+
+// PageDTO describes the page returned by a list query
+type PageDTO struct {
+	Total         int // only counted for offset paging
+	NextPageToken string
 }
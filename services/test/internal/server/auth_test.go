@@ -0,0 +1,21 @@
+package server
+
+import (
+	"testing"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/test/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/auth/authztest"
+)
+
+func TestPolicy(t *testing.T) {
+	authztest.Run(t, policy, []authztest.Case{
+		{Operation: v1.Test_CreateTest_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_GetTest_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_ListTest_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_UpdateTest_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_DeleteTest_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_RequestFileUpload_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.Test_ConfirmFileUpload_FullMethodName, Role: auth.RoleUser, Allowed: true},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	pb "github.com/reverny/kratos-mono/gen/go/api/test/v1"
+	"github.com/reverny/kratos-mono/pkg/pagination"
 	"github.com/reverny/kratos-mono/services/test/internal/biz"
 	"github.com/reverny/kratos-mono/services/test/internal/dto"
 )
@@ -50,10 +51,12 @@ func (s *TestService) GetTest(ctx context.Context, req *pb.GetTestRequest) (*pb.
 }
 
 func (s *TestService) ListTest(ctx context.Context, req *pb.ListTestRequest) (*pb.ListTestReply, error) {
-	items, total, err := s.uc.List(ctx, &dto.ListTestQuery{
-		Page:     req.Page,
-		PageSize: req.PageSize,
-	})
+	query := &dto.ListTestQuery{
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	}
+	items, page, err := s.uc.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +70,9 @@ func (s *TestService) ListTest(ctx context.Context, req *pb.ListTestRequest) (*p
 	}
 	
 	return &pb.ListTestReply{
-		Items: pbItems,
-		Total: int32(total),
+		Items:      pbItems,
+		Total:      int32(page.Total),
+		Pagination: pagination.Proto(query.Page, query.PageSize, int32(page.Total), page.NextPageToken),
 	}, nil
 }
 
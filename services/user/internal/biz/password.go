@@ -0,0 +1,223 @@
+package biz
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+// Supported values for conf.Auth.Password.Algorithm.
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// Defaults for unset conf.Auth.Password fields.
+const (
+	defaultBcryptCost      = 12
+	defaultArgon2Time      = 3
+	defaultArgon2MemoryKiB = 64 * 1024
+	defaultArgon2Threads   = 2
+	defaultMinLength       = 8
+)
+
+const (
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+	// maxPasswordBytes bounds the work a single Login can cause and matches
+	// the input limit of bcrypt.
+	maxPasswordBytes = 72
+)
+
+// ErrInvalidCredentials is returned by Login for an unknown username and for
+// a wrong password alike, so callers cannot tell which one was wrong.
+var ErrInvalidCredentials = errors.Unauthorized(common.ErrorCode_UNAUTHENTICATED.String(), "invalid username or password")
+
+// PasswordHasher hashes passwords with the configured algorithm and verifies
+// them against hashes produced by either algorithm.
+type PasswordHasher struct {
+	algorithm  string
+	bcryptCost int
+	time       uint32
+	memory     uint32
+	threads    uint8
+	minLength  int
+
+	// dummy is verified against when the user does not exist, so that an
+	// unknown username costs as much as a wrong password.
+	dummy string
+}
+
+// NewPasswordHasher new a PasswordHasher from c.Password.
+func NewPasswordHasher(c *conf.Auth) (*PasswordHasher, error) {
+	pc := c.GetPassword()
+	h := &PasswordHasher{
+		algorithm:  pc.GetAlgorithm(),
+		bcryptCost: int(pc.GetBcryptCost()),
+		time:       pc.GetArgon2Time(),
+		memory:     pc.GetArgon2MemoryKib(),
+		threads:    uint8(pc.GetArgon2Threads()),
+		minLength:  int(pc.GetMinLength()),
+	}
+	switch h.algorithm {
+	case "":
+		h.algorithm = AlgorithmArgon2id
+	case AlgorithmArgon2id, AlgorithmBcrypt:
+	default:
+		return nil, fmt.Errorf("unsupported password algorithm: %q", h.algorithm)
+	}
+	if h.bcryptCost == 0 {
+		h.bcryptCost = defaultBcryptCost
+	}
+	if h.bcryptCost < bcrypt.MinCost || h.bcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost %d out of range [%d, %d]", h.bcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	if h.time == 0 {
+		h.time = defaultArgon2Time
+	}
+	if h.memory == 0 {
+		h.memory = defaultArgon2MemoryKiB
+	}
+	if h.threads == 0 {
+		h.threads = defaultArgon2Threads
+	}
+	if h.minLength == 0 {
+		h.minLength = defaultMinLength
+	}
+
+	dummy, err := h.Hash("dummy-password-0")
+	if err != nil {
+		return nil, err
+	}
+	h.dummy = dummy
+	return h, nil
+}
+
+// Validate checks password against the password policy: at least minLength
+// characters, at most 72 bytes, letters and digits both present, and not
+// the username itself.
+func (h *PasswordHasher) Validate(username, password string) error {
+	if utf8.RuneCountInString(password) < h.minLength {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(),
+			fmt.Sprintf("password must be at least %d characters", h.minLength))
+	}
+	if len(password) > maxPasswordBytes {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(),
+			fmt.Sprintf("password must be at most %d bytes", maxPasswordBytes))
+	}
+	var letter, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	if !letter || !digit {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "password must contain both letters and digits")
+	}
+	if strings.EqualFold(password, username) {
+		return errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "password must not be the username")
+	}
+	return nil
+}
+
+// Hash returns the encoded hash of password. Argon2id hashes use the PHC
+// string format, e.g. "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>".
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("hash password: %w", err)
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify reports whether password matches encoded in constant time, and
+// whether encoded should be replaced by a fresh Hash because it was made
+// with another algorithm or other cost parameters.
+func (h *PasswordHasher) Verify(encoded, password string) (ok, rehash bool, err error) {
+	if strings.HasPrefix(encoded, "$argon2id$") {
+		p, err := parseArgon2id(encoded)
+		if err != nil {
+			return false, false, err
+		}
+		key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return false, false, nil
+		}
+		rehash = h.algorithm != AlgorithmArgon2id ||
+			p.time != h.time || p.memory != h.memory || p.threads != h.threads ||
+			len(p.salt) != argon2SaltLen || len(p.key) != argon2KeyLen
+		return true, rehash, nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("verify password: %w", err)
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, false, fmt.Errorf("verify password: %w", err)
+	}
+	return true, h.algorithm != AlgorithmBcrypt || cost != h.bcryptCost, nil
+}
+
+// VerifyDummy spends the time of a Verify without a stored hash.
+func (h *PasswordHasher) VerifyDummy(password string) {
+	_, _, _ = h.Verify(h.dummy, password)
+}
+
+type argon2Params struct {
+	memory, time uint32
+	threads      uint8
+	salt, key    []byte
+}
+
+func parseArgon2id(encoded string) (*argon2Params, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("verify password: malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, fmt.Errorf("verify password: unsupported argon2id version %q", parts[2])
+	}
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return nil, fmt.Errorf("verify password: malformed argon2id parameters: %w", err)
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("verify password: malformed argon2id salt: %w", err)
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, fmt.Errorf("verify password: malformed argon2id key")
+	}
+	return &p, nil
+}
@@ -0,0 +1,216 @@
+package biz
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+// newTestHasher returns a hasher with cheap cost parameters.
+func newTestHasher(t *testing.T, pc *conf.Auth_Password) *PasswordHasher {
+	t.Helper()
+	if pc.BcryptCost == 0 {
+		pc.BcryptCost = 4
+	}
+	if pc.Argon2MemoryKib == 0 {
+		pc.Argon2MemoryKib = 1024
+	}
+	if pc.Argon2Time == 0 {
+		pc.Argon2Time = 1
+	}
+	h, err := NewPasswordHasher(&conf.Auth{Password: pc})
+	if err != nil {
+		t.Fatalf("NewPasswordHasher: %v", err)
+	}
+	return h
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	h := newTestHasher(t, &conf.Auth_Password{})
+	cases := []struct {
+		name, password string
+		ok             bool
+	}{
+		{"valid", "correct9horse", true},
+		{"too short", "abc123", false},
+		{"too long", strings.Repeat("a1", 37), false},
+		{"no digit", "onlyletters", false},
+		{"no letter", "1234567890", false},
+		{"username", "Alice2024", false},
+		{"multibyte letters", "รหัสผ่าน12", true},
+	}
+	for _, c := range cases {
+		err := h.Validate("alice2024", c.password)
+		if c.ok && err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+		if !c.ok && errors.Reason(err) != common.ErrorCode_INVALID_ARGUMENT.String() {
+			t.Errorf("%s: got %v, want INVALID_ARGUMENT", c.name, err)
+		}
+	}
+}
+
+func TestHashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := newTestHasher(t, &conf.Auth_Password{Algorithm: algorithm})
+			hash, err := h.Hash("correct9horse")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if other, _ := h.Hash("correct9horse"); other == hash {
+				t.Fatal("two hashes of a password are equal; salt is missing")
+			}
+
+			if ok, rehash, err := h.Verify(hash, "correct9horse"); !ok || rehash || err != nil {
+				t.Fatalf("Verify(right password) = %v, %v, %v", ok, rehash, err)
+			}
+			if ok, _, err := h.Verify(hash, "wrong9horse"); ok || err != nil {
+				t.Fatalf("Verify(wrong password) = %v, %v", ok, err)
+			}
+		})
+	}
+
+	h := newTestHasher(t, &conf.Auth_Password{})
+	for _, malformed := range []string{"$argon2id$v=19$m=1024", "$argon2id$v=18$m=1024,t=1,p=2$c2FsdA$a2V5", "plain"} {
+		if ok, _, err := h.Verify(malformed, "correct9horse"); ok || err == nil {
+			t.Errorf("Verify(%q) = %v, %v; want an error", malformed, ok, err)
+		}
+	}
+}
+
+func TestVerifyAsksForRehash(t *testing.T) {
+	old := newTestHasher(t, &conf.Auth_Password{Argon2Time: 1})
+	hash, err := old.Hash("correct9horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := newTestHasher(t, &conf.Auth_Password{Algorithm: AlgorithmBcrypt}).Hash("correct9horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		current *PasswordHasher
+		hash    string
+	}{
+		{"argon2 time raised", newTestHasher(t, &conf.Auth_Password{Argon2Time: 2}), hash},
+		{"argon2 memory raised", newTestHasher(t, &conf.Auth_Password{Argon2MemoryKib: 2048}), hash},
+		{"switched to bcrypt", newTestHasher(t, &conf.Auth_Password{Algorithm: AlgorithmBcrypt}), hash},
+		{"bcrypt cost raised", newTestHasher(t, &conf.Auth_Password{Algorithm: AlgorithmBcrypt, BcryptCost: 5}), bcryptHash},
+		{"switched to argon2id", newTestHasher(t, &conf.Auth_Password{}), bcryptHash},
+	}
+	for _, c := range cases {
+		ok, rehash, err := c.current.Verify(c.hash, "correct9horse")
+		if !ok || !rehash || err != nil {
+			t.Errorf("%s: Verify = %v, %v, %v; want ok and rehash", c.name, ok, rehash, err)
+		}
+	}
+}
+
+// loginRepo holds users in memory, keyed by username.
+type loginRepo struct {
+	UserRepo
+	users  map[string]*v1.UserInfo
+	hashes map[string]string
+}
+
+func (r *loginRepo) GetUserByUsername(_ context.Context, username string) (*v1.UserInfo, string, error) {
+	u, ok := r.users[username]
+	if !ok {
+		return nil, "", ErrUserNotFound
+	}
+	return u, r.hashes[u.Id], nil
+}
+
+func (r *loginRepo) UpdatePasswordHash(_ context.Context, id, hash string) error {
+	r.hashes[id] = hash
+	return nil
+}
+
+// memRefreshTokens stores refresh tokens in memory.
+type memRefreshTokens struct {
+	RefreshTokenRepo
+	tokens []*RefreshToken
+}
+
+func (r *memRefreshTokens) CreateRefreshToken(_ context.Context, t *RefreshToken) error {
+	r.tokens = append(r.tokens, t)
+	return nil
+}
+
+// newTestIssuer returns a TokenIssuer signing with a fresh ES256 key.
+func newTestIssuer(t *testing.T, jc *conf.Auth_JWT) *TokenIssuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jc.PrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	issuer, err := NewTokenIssuer(&conf.Auth{Jwt: jc})
+	if err != nil {
+		t.Fatalf("NewTokenIssuer: %v", err)
+	}
+	return issuer
+}
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	old := newTestHasher(t, &conf.Auth_Password{Argon2Time: 1})
+	hash, err := old.Hash("correct9horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &loginRepo{
+		users:  map[string]*v1.UserInfo{"alice": {Id: "u1", Username: "alice", Role: "user"}},
+		hashes: map[string]string{"u1": hash},
+	}
+	refreshTokens := &memRefreshTokens{}
+	// The configured cost is higher than the one alice's hash was made with.
+	hasher := newTestHasher(t, &conf.Auth_Password{Argon2Time: 2})
+	uc := NewUserUsecase(repo, refreshTokens, hasher, newTestIssuer(t, &conf.Auth_JWT{}), log.DefaultLogger)
+
+	for _, c := range []struct{ name, username, password string }{
+		{"wrong password", "alice", "wrong9horse"},
+		{"unknown user", "mallory", "correct9horse"},
+	} {
+		_, err := uc.Login(ctx, &v1.LoginRequest{Username: c.username, Password: c.password})
+		if !errors.Is(err, ErrInvalidCredentials) || errors.Reason(err) != common.ErrorCode_UNAUTHENTICATED.String() {
+			t.Errorf("%s: got %v, want UNAUTHENTICATED invalid credentials", c.name, err)
+		}
+	}
+	if repo.hashes["u1"] != hash || len(refreshTokens.tokens) != 0 {
+		t.Fatal("a failed login changed the stored hash or issued a refresh token")
+	}
+
+	resp, err := uc.Login(ctx, &v1.LoginRequest{Username: "alice", Password: "correct9horse"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.Token == "" || resp.RefreshToken == "" || resp.User.GetId() != "u1" || len(refreshTokens.tokens) != 1 {
+		t.Fatalf("Login returned %+v", resp)
+	}
+	if repo.hashes["u1"] == hash {
+		t.Fatal("the outdated hash was not replaced")
+	}
+	if ok, rehash, err := hasher.Verify(repo.hashes["u1"], "correct9horse"); !ok || rehash || err != nil {
+		t.Fatalf("rehashed password: Verify = %v, %v, %v", ok, rehash, err)
+	}
+}
@@ -0,0 +1,303 @@
+package biz
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+// Defaults for unset conf.Auth.JWT fields.
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// refreshTokenBytes is the entropy of a refresh token.
+const refreshTokenBytes = 32
+
+var (
+	// ErrInvalidRefreshToken is returned for an unknown, expired or revoked refresh token.
+	ErrInvalidRefreshToken = errors.Unauthorized(common.ErrorCode_UNAUTHENTICATED.String(), "invalid refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token that was already
+	// rotated is presented again; every token of its family is then revoked.
+	ErrRefreshTokenReused = errors.Unauthorized(common.ErrorCode_UNAUTHENTICATED.String(), "refresh token reuse detected; session revoked")
+)
+
+// RefreshToken is a server-side record of an issued refresh token. Only the
+// SHA-256 of the token is stored. Tokens rotated from the same login share a
+// FamilyID.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time // set once the token was rotated
+	RevokedAt *time.Time
+}
+
+// RefreshTokenRepo is a refresh token repo.
+type RefreshTokenRepo interface {
+	CreateRefreshToken(context.Context, *RefreshToken) error
+	// RotateRefreshToken marks the token with tokenHash used and stores next
+	// in its family, returning the rotated token. A token that was already
+	// rotated fails with ErrRefreshTokenReused after its family has been
+	// revoked; an unknown, expired or revoked one with ErrInvalidRefreshToken.
+	RotateRefreshToken(ctx context.Context, tokenHash string, next *RefreshToken) (*RefreshToken, error)
+	// RevokeRefreshTokenFamily revokes the family of the token with tokenHash.
+	// An unknown token is not an error.
+	RevokeRefreshTokenFamily(ctx context.Context, tokenHash string) error
+}
+
+// TokenIssuer signs access tokens and mints refresh tokens.
+type TokenIssuer struct {
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	// keys are ordered by activeFrom.
+	keys []*signingKey
+}
+
+// signingKey is a key of conf.Auth.JWT.Keys. It signs tokens from activeFrom
+// until the next key becomes active, and is published until retireAt.
+type signingKey struct {
+	kid        string
+	method     jwt.SigningMethod
+	key        crypto.Signer
+	activeFrom time.Time
+	retireAt   time.Time // zero: never
+}
+
+func (k *signingKey) published(now time.Time) bool {
+	return k.retireAt.IsZero() || now.Before(k.retireAt)
+}
+
+// NewTokenIssuer new a TokenIssuer from c.Jwt.
+func NewTokenIssuer(c *conf.Auth) (*TokenIssuer, error) {
+	jc := c.GetJwt()
+	t := &TokenIssuer{
+		issuer:     jc.GetIssuer(),
+		audience:   jc.GetAudience(),
+		accessTTL:  jc.GetAccessTtl().AsDuration(),
+		refreshTTL: jc.GetRefreshTtl().AsDuration(),
+	}
+	if t.accessTTL <= 0 {
+		t.accessTTL = defaultAccessTTL
+	}
+	if t.refreshTTL <= 0 {
+		t.refreshTTL = defaultRefreshTTL
+	}
+
+	keys := jc.GetKeys()
+	if len(keys) == 0 {
+		keys = []*conf.Auth_JWT_Key{{Kid: jc.GetKeyId(), PrivateKey: jc.GetPrivateKey()}}
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, kc := range keys {
+		if len(keys) > 1 && kc.GetKid() == "" {
+			return nil, fmt.Errorf("auth.jwt.keys: every key needs a kid")
+		}
+		if seen[kc.GetKid()] {
+			return nil, fmt.Errorf("auth.jwt.keys: duplicate kid %q", kc.GetKid())
+		}
+		seen[kc.GetKid()] = true
+
+		key, method, err := parseSigningKey(kc.GetPrivateKey())
+		if err != nil {
+			return nil, fmt.Errorf("auth.jwt key %q: %w", kc.GetKid(), err)
+		}
+		k := &signingKey{kid: kc.GetKid(), method: method, key: key}
+		if kc.GetActiveFrom() != nil {
+			k.activeFrom = kc.GetActiveFrom().AsTime()
+		}
+		if kc.GetRetireAt() != nil {
+			k.retireAt = kc.GetRetireAt().AsTime()
+		}
+		t.keys = append(t.keys, k)
+	}
+	sort.SliceStable(t.keys, func(i, j int) bool {
+		return t.keys[i].activeFrom.Before(t.keys[j].activeFrom)
+	})
+
+	// A key must stay published until the tokens it signed have expired.
+	for i, k := range t.keys[:len(t.keys)-1] {
+		next := t.keys[i+1]
+		if !k.retireAt.IsZero() && k.retireAt.Before(next.activeFrom.Add(t.accessTTL)) {
+			return nil, fmt.Errorf("auth.jwt key %q must not retire before %s, access_ttl after key %q becomes active",
+				k.kid, next.activeFrom.Add(t.accessTTL).Format(time.RFC3339), next.kid)
+		}
+	}
+	if t.signingKey(time.Now()) == nil {
+		return nil, fmt.Errorf("auth.jwt: no signing key is active")
+	}
+	return t, nil
+}
+
+// signingKey returns the key that signs at now, or nil.
+func (t *TokenIssuer) signingKey(now time.Time) *signingKey {
+	for i := len(t.keys) - 1; i >= 0; i-- {
+		k := t.keys[i]
+		if !k.activeFrom.After(now) && k.published(now) {
+			return k
+		}
+	}
+	return nil
+}
+
+// JWKS returns the public keys that verify tokens: the signing key, the keys
+// it replaced until they retire, and the keys scheduled to take over.
+func (t *TokenIssuer) JWKS() (*auth.JWKS, error) {
+	now := time.Now()
+	set := &auth.JWKS{Keys: make([]auth.JWK, 0, len(t.keys))}
+	for _, k := range t.keys {
+		if !k.published(now) {
+			continue
+		}
+		jwk, err := auth.NewJWK(k.kid, k.key.Public())
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
+
+// Keyfunc resolves the key of a token this issuer signed, for verifying
+// tokens without fetching the JWKS.
+func (t *TokenIssuer) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	now := time.Now()
+	for _, k := range t.keys {
+		if k.kid == kid && k.published(now) {
+			return k.key.Public(), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// parseSigningKey decodes a PEM private key and picks its JWT algorithm.
+func parseSigningKey(data string) (crypto.Signer, jwt.SigningMethod, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, nil, fmt.Errorf("no PEM private key")
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, nil, fmt.Errorf("EC keys must use P-256")
+		}
+		return k, jwt.SigningMethodES256, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// AccessTTL is the lifetime of the access tokens Sign returns.
+func (t *TokenIssuer) AccessTTL() time.Duration {
+	return t.accessTTL
+}
+
+// Sign returns a signed access token for user.
+func (t *TokenIssuer) Sign(user *v1.UserInfo) (string, error) {
+	now := time.Now()
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    t.issuer,
+			Subject:   user.Id,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(t.accessTTL)),
+		},
+		Username: user.Username,
+		Role:     user.Role,
+	}
+	if t.audience != "" {
+		claims.Audience = jwt.ClaimStrings{t.audience}
+	}
+
+	k := t.signingKey(now)
+	if k == nil {
+		return "", fmt.Errorf("sign access token: no signing key is active")
+	}
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.kid != "" {
+		token.Header["kid"] = k.kid
+	}
+	signed, err := token.SignedString(k.key)
+	if err != nil {
+		return "", fmt.Errorf("sign access token: %w", err)
+	}
+	return signed, nil
+}
+
+// NewRefreshToken returns a fresh refresh token and its record. The record
+// belongs to familyID, or starts a new family when familyID is empty.
+func (t *TokenIssuer) NewRefreshToken(userID, familyID string) (string, *RefreshToken, error) {
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	return token, &RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(token),
+		ExpiresAt: now.Add(t.refreshTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+// HashRefreshToken returns the hex SHA-256 under which token is stored.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package biz
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+func TestSignCarriesConfiguredClaims(t *testing.T) {
+	issuer := newTestIssuer(t, &conf.Auth_JWT{
+		Issuer:    "https://auth.example.com",
+		Audience:  "kratos-mono",
+		AccessTtl: durationpb.New(5 * time.Minute),
+		KeyId:     "k1",
+	})
+
+	token, err := issuer.Sign(&v1.UserInfo{Id: "u1", Username: "alice", Role: auth.RoleAdmin})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	claims, err := auth.NewVerifier(issuer.Keyfunc, "https://auth.example.com", "kratos-mono").Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.UserID() != "u1" || claims.Username != "alice" || claims.Role != auth.RoleAdmin {
+		t.Fatalf("claims %+v", claims)
+	}
+	if ttl := claims.ExpiresAt.Sub(claims.IssuedAt.Time); ttl != 5*time.Minute {
+		t.Fatalf("token lives %s, want 5m", ttl)
+	}
+	if issuer.AccessTTL() != 5*time.Minute {
+		t.Fatalf("AccessTTL = %s", issuer.AccessTTL())
+	}
+
+	for _, v := range []*auth.Verifier{
+		auth.NewVerifier(issuer.Keyfunc, "https://other.example.com", "kratos-mono"),
+		auth.NewVerifier(issuer.Keyfunc, "https://auth.example.com", "other-audience"),
+	} {
+		if _, err := v.Verify(token); err == nil {
+			t.Error("token accepted by a verifier expecting another issuer or audience")
+		}
+	}
+}
+
+func TestNewRefreshTokenStartsOrJoinsAFamily(t *testing.T) {
+	issuer := newTestIssuer(t, &conf.Auth_JWT{RefreshTtl: durationpb.New(time.Hour)})
+
+	token, record, err := issuer.NewRefreshToken("u1", "")
+	if err != nil {
+		t.Fatalf("NewRefreshToken: %v", err)
+	}
+	if record.FamilyID == "" || record.TokenHash != HashRefreshToken(token) || record.TokenHash == token {
+		t.Fatalf("record %+v", record)
+	}
+	if ttl := record.ExpiresAt.Sub(record.CreatedAt); ttl != time.Hour {
+		t.Fatalf("refresh token lives %s, want 1h", ttl)
+	}
+
+	_, next, err := issuer.NewRefreshToken("u1", record.FamilyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.FamilyID != record.FamilyID || next.TokenHash == record.TokenHash {
+		t.Fatalf("rotated record %+v", next)
+	}
+}
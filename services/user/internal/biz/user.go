@@ -3,42 +3,95 @@ package biz
 import (
 	"context"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
+	"github.com/reverny/kratos-mono/gen/go/api/common"
 	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/errcode"
+	"github.com/reverny/kratos-mono/pkg/fieldmask"
+	"github.com/reverny/kratos-mono/pkg/pagination"
+)
+
+// User fields accepted in UpdateUserRequest.update_mask.
+const (
+	UserFieldEmail     = "email"
+	UserFieldFullName  = "full_name"
+	UserFieldAvatarURL = "avatar_url"
+)
+
+var (
+	// ErrUserNotFound is returned when no user exists for the given id or username.
+	ErrUserNotFound = errors.NotFound(common.ErrorCode_NOT_FOUND.String(), "user not found")
+	// ErrUsernameAlreadyExists is returned when another user already has the username.
+	ErrUsernameAlreadyExists = errcode.AlreadyExists("username already exists")
+	// ErrNotAccountOwner is returned when a non-admin reads or updates another user.
+	ErrNotAccountOwner = errors.Forbidden(common.ErrorCode_PERMISSION_DENIED.String(), "only the account owner or an admin may access this user")
 )
 
 // UserRepo is a User repo.
 type UserRepo interface {
-	CreateUser(context.Context, *v1.CreateUserRequest) (*v1.UserInfo, error)
+	// CreateUser stores the user with passwordHash; req.Password is ignored.
+	CreateUser(ctx context.Context, req *v1.CreateUserRequest, passwordHash string) (*v1.UserInfo, error)
 	GetUser(context.Context, string) (*v1.UserInfo, error)
+	// GetUserByUsername returns the user and its password hash.
 	GetUserByUsername(context.Context, string) (*v1.UserInfo, string, error)
-	ListUsers(context.Context, int32, int32, string, string) ([]*v1.UserInfo, int32, error)
-	UpdateUser(context.Context, *v1.UpdateUserRequest) (*v1.UserInfo, error)
+	UpdatePasswordHash(ctx context.Context, id, passwordHash string) error
+	// ListUsers returns a page of users, the total (offset paging only) and
+	// the token of the next page.
+	ListUsers(ctx context.Context, page, pageSize int32, role, status, pageToken string) ([]*v1.UserInfo, int32, string, error)
+	// UpdateUser writes only the fields named in req.UpdateMask, which biz
+	// always fills in.
+	UpdateUser(ctx context.Context, req *v1.UpdateUserRequest) (*v1.UserInfo, error)
 	DeleteUser(context.Context, string) error
 }
 
 // UserUsecase is a User usecase.
 type UserUsecase struct {
-	repo UserRepo
-	log  *log.Helper
+	repo          UserRepo
+	refreshTokens RefreshTokenRepo
+	passwords     *PasswordHasher
+	tokens        *TokenIssuer
+	log           *log.Helper
 }
 
 // NewUserUsecase new a User usecase.
-func NewUserUsecase(repo UserRepo, logger log.Logger) *UserUsecase {
-	return &UserUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewUserUsecase(repo UserRepo, refreshTokens RefreshTokenRepo, passwords *PasswordHasher, tokens *TokenIssuer, logger log.Logger) *UserUsecase {
+	return &UserUsecase{
+		repo:          repo,
+		refreshTokens: refreshTokens,
+		passwords:     passwords,
+		tokens:        tokens,
+		log:           log.NewHelper(logger),
+	}
 }
 
-// CreateUser creates a User.
+// CreateUser creates a User. Only the hash of req.Password is stored.
 func (uc *UserUsecase) CreateUser(ctx context.Context, req *v1.CreateUserRequest) (*v1.UserInfo, error) {
 	uc.log.WithContext(ctx).Infof("CreateUser: %v", req.Username)
-	return uc.repo.CreateUser(ctx, req)
+
+	if req.Username == "" {
+		return nil, errors.BadRequest(common.ErrorCode_INVALID_ARGUMENT.String(), "username is required")
+	}
+	if err := uc.passwords.Validate(req.Username, req.Password); err != nil {
+		return nil, err
+	}
+	hash, err := uc.passwords.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.CreateUser(ctx, req, hash)
 }
 
-// GetUser gets a User by ID.
+// GetUser gets a User by ID. Only the user itself or an admin may read it.
 func (uc *UserUsecase) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1.UserInfo, error) {
 	uc.log.WithContext(ctx).Infof("GetUser: %v", req.Id)
+	if err := authorizeOwner(ctx, req.Id); err != nil {
+		return nil, err
+	}
 	return uc.repo.GetUser(ctx, req.Id)
 }
 
@@ -46,30 +99,64 @@ func (uc *UserUsecase) GetUser(ctx context.Context, req *v1.GetUserRequest) (*v1
 func (uc *UserUsecase) ListUsers(ctx context.Context, req *v1.ListUsersRequest) (*v1.ListUsersResponse, error) {
 	uc.log.WithContext(ctx).Infof("ListUsers: page=%d, page_size=%d", req.Page, req.PageSize)
 
-	if req.Page == 0 {
+	if req.PageToken != "" {
+		// The token takes over from the page number.
+		req.Page = 0
+	} else if req.Page <= 0 {
 		req.Page = 1
 	}
-	if req.PageSize == 0 {
+	if req.PageSize <= 0 {
 		req.PageSize = 10
 	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
 
-	users, total, err := uc.repo.ListUsers(ctx, req.Page, req.PageSize, req.Role, req.Status)
+	users, total, next, err := uc.repo.ListUsers(ctx, req.Page, req.PageSize, req.Role, req.Status, req.PageToken)
 	if err != nil {
 		return nil, err
 	}
 
 	return &v1.ListUsersResponse{
-		Users: users,
-		Total: total,
+		Users:      users,
+		Total:      total,
+		Pagination: pagination.Proto(req.Page, req.PageSize, total, next),
 	}, nil
 }
 
-// UpdateUser updates a User.
+// UpdateUser updates a User. Only the user itself or an admin may update it.
 func (uc *UserUsecase) UpdateUser(ctx context.Context, req *v1.UpdateUserRequest) (*v1.UserInfo, error) {
 	uc.log.WithContext(ctx).Infof("UpdateUser: %v", req.Id)
+	if err := authorizeOwner(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	// Without a mask every field is replaced, as before update_mask existed.
+	paths, err := fieldmask.Validate(req.GetUpdateMask().GetPaths(), UserFieldEmail, UserFieldFullName, UserFieldAvatarURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = []string{UserFieldEmail, UserFieldFullName, UserFieldAvatarURL}
+	}
+	req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+
 	return uc.repo.UpdateUser(ctx, req)
 }
 
+// authorizeOwner lets the user whose id is given, or an admin, through. The
+// policy only ranks roles, so the owner check lives here.
+func authorizeOwner(ctx context.Context, id string) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return auth.ErrMissingToken
+	}
+	if claims.UserID() != id && !auth.HasRole(claims.Role, auth.RoleAdmin) {
+		return ErrNotAccountOwner
+	}
+	return nil
+}
+
 // DeleteUser deletes a User.
 func (uc *UserUsecase) DeleteUser(ctx context.Context, req *v1.DeleteUserRequest) (*emptypb.Empty, error) {
 	uc.log.WithContext(ctx).Infof("DeleteUser: %v", req.Id)
@@ -80,23 +167,107 @@ func (uc *UserUsecase) DeleteUser(ctx context.Context, req *v1.DeleteUserRequest
 	return &emptypb.Empty{}, nil
 }
 
-// Login authenticates a user.
+// Login authenticates a user. An unknown username and a wrong password both
+// fail with ErrInvalidCredentials after the same amount of hashing work.
 func (uc *UserUsecase) Login(ctx context.Context, req *v1.LoginRequest) (*v1.LoginResponse, error) {
 	uc.log.WithContext(ctx).Infof("Login: %v", req.Username)
 
 	user, passwordHash, err := uc.repo.GetUserByUsername(ctx, req.Username)
+	if errors.IsNotFound(err) {
+		uc.passwords.VerifyDummy(req.Password)
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Verify password with bcrypt
-	_ = passwordHash
+	ok, rehash, err := uc.passwords.Verify(passwordHash, req.Password)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("Login: user %s: %v", user.Id, err)
+		return nil, ErrInvalidCredentials
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	if rehash {
+		// The login already succeeded; a failed upgrade is retried next time.
+		if hash, err := uc.passwords.Hash(req.Password); err != nil {
+			uc.log.WithContext(ctx).Warnf("Login: rehash password of user %s: %v", user.Id, err)
+		} else if err := uc.repo.UpdatePasswordHash(ctx, user.Id, hash); err != nil {
+			uc.log.WithContext(ctx).Warnf("Login: rehash password of user %s: %v", user.Id, err)
+		}
+	}
 
-	// TODO: Generate JWT token
-	token := "sample-jwt-token"
+	refreshToken, record, err := uc.tokens.NewRefreshToken(user.Id, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.refreshTokens.CreateRefreshToken(ctx, record); err != nil {
+		return nil, err
+	}
+	token, err := uc.tokens.Sign(user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &v1.LoginResponse{
-		Token: token,
-		User:  user,
+		Token:        token,
+		User:         user,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(uc.tokens.AccessTTL().Seconds()),
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. The presented token cannot be used again.
+func (uc *UserUsecase) RefreshToken(ctx context.Context, req *v1.RefreshTokenRequest) (*v1.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// The family and user are filled in from the rotated token.
+	refreshToken, next, err := uc.tokens.NewRefreshToken("", "")
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := uc.refreshTokens.RotateRefreshToken(ctx, HashRefreshToken(req.RefreshToken), next)
+	if err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("RefreshToken: user %s", rotated.UserID)
+
+	user, err := uc.repo.GetUser(ctx, rotated.UserID)
+	if errors.IsNotFound(err) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	token, err := uc.tokens.Sign(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RefreshTokenResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(uc.tokens.AccessTTL().Seconds()),
 	}, nil
 }
+
+// Logout revokes the refresh token and every token rotated from the same
+// login. Access tokens already issued stay valid until they expire.
+func (uc *UserUsecase) Logout(ctx context.Context, req *v1.LogoutRequest) (*emptypb.Empty, error) {
+	if req.RefreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := uc.refreshTokens.RevokeRefreshTokenFamily(ctx, HashRefreshToken(req.RefreshToken)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// JWKS returns the public keys that verify the access tokens this service issues.
+func (uc *UserUsecase) JWKS() (*auth.JWKS, error) {
+	return uc.tokens.JWKS()
+}
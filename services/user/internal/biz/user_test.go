@@ -0,0 +1,60 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/reverny/kratos-mono/gen/go/api/common"
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+)
+
+// usersRepo returns the requested user as is.
+type usersRepo struct {
+	UserRepo
+}
+
+func (usersRepo) GetUser(_ context.Context, id string) (*v1.UserInfo, error) {
+	return &v1.UserInfo{Id: id}, nil
+}
+
+func (usersRepo) UpdateUser(_ context.Context, req *v1.UpdateUserRequest) (*v1.UserInfo, error) {
+	return &v1.UserInfo{Id: req.Id, Email: req.Email}, nil
+}
+
+func TestOnlyOwnerOrAdminAccessesUser(t *testing.T) {
+	uc := NewUserUsecase(usersRepo{}, nil, nil, nil, log.DefaultLogger)
+	as := func(id, role string) context.Context {
+		claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: id}, Role: role}
+		return auth.NewContext(context.Background(), claims, "")
+	}
+
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		reason string
+	}{
+		{"owner", as("u1", auth.RoleUser), ""},
+		{"admin", as("admin", auth.RoleAdmin), ""},
+		{"other user", as("u2", auth.RoleUser), common.ErrorCode_PERMISSION_DENIED.String()},
+		{"anonymous", context.Background(), common.ErrorCode_UNAUTHENTICATED.String()},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, getErr := uc.GetUser(c.ctx, &v1.GetUserRequest{Id: "u1"})
+			_, updateErr := uc.UpdateUser(c.ctx, &v1.UpdateUserRequest{Id: "u1", Email: "u1@example.com"})
+			for op, err := range map[string]error{"GetUser": getErr, "UpdateUser": updateErr} {
+				if c.reason == "" && err != nil {
+					t.Errorf("%s: %v", op, err)
+				}
+				if c.reason != "" && errors.Reason(err) != c.reason {
+					t.Errorf("%s: got %v, want %s", op, err, c.reason)
+				}
+			}
+		})
+	}
+}
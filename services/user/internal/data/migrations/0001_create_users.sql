@@ -0,0 +1,18 @@
+-- Users of the platform. password_hash holds an argon2id (PHC string) or
+-- bcrypt hash; the plain password is never stored.
+CREATE TABLE users (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    username VARCHAR(64) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    full_name VARCHAR(255) NOT NULL,
+    avatar_url VARCHAR(1024) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX uq_users_username ON users (username);
+
+CREATE INDEX idx_users_role_status ON users (role, status);
@@ -0,0 +1,19 @@
+-- Refresh tokens, stored as the hex SHA-256 of the token. Tokens rotated
+-- from the same login share a family_id; presenting a token whose used_at
+-- is set again revokes the whole family.
+CREATE TABLE refresh_tokens (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    family_id VARCHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    revoked_at DATETIME NULL
+);
+
+CREATE UNIQUE INDEX uq_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/reverny/kratos-mono/services/user/internal/biz"
+)
+
+type refreshTokenRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewRefreshTokenRepo .
+func NewRefreshTokenRepo(data *Data, logger log.Logger) biz.RefreshTokenRepo {
+	return &refreshTokenRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *refreshTokenRepo) CreateRefreshToken(ctx context.Context, t *biz.RefreshToken) error {
+	if _, err := r.data.Conn(ctx).ExecContext(ctx,
+		`INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		t.ID, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt, t.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepo) RotateRefreshToken(ctx context.Context, tokenHash string, next *biz.RefreshToken) (*biz.RefreshToken, error) {
+	var (
+		current *biz.RefreshToken
+		reused  bool
+	)
+	// A reused token revokes its family, which must be committed even though
+	// the rotation itself fails.
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		current, err = r.find(ctx, tokenHash)
+		if err != nil {
+			return err
+		}
+		now := nowUTC()
+		if current.UsedAt != nil {
+			reused = true
+			return r.revokeFamily(ctx, current.FamilyID)
+		}
+		if current.RevokedAt != nil || !now.Before(current.ExpiresAt) {
+			return biz.ErrInvalidRefreshToken
+		}
+
+		if _, err := r.data.Conn(ctx).ExecContext(ctx,
+			`UPDATE refresh_tokens SET used_at = ? WHERE id = ?`, now, current.ID,
+		); err != nil {
+			return fmt.Errorf("rotate refresh token: %w", err)
+		}
+		next.UserID, next.FamilyID = current.UserID, current.FamilyID
+		return r.CreateRefreshToken(ctx, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		r.log.Warnf("Refresh token reused, revoked family %s of user %s", current.FamilyID, current.UserID)
+		return current, biz.ErrRefreshTokenReused
+	}
+	return current, nil
+}
+
+func (r *refreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, tokenHash string) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		current, err := r.find(ctx, tokenHash)
+		if errors.Is(err, biz.ErrInvalidRefreshToken) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return r.revokeFamily(ctx, current.FamilyID)
+	})
+}
+
+// find locks and returns the token with tokenHash.
+func (r *refreshTokenRepo) find(ctx context.Context, tokenHash string) (*biz.RefreshToken, error) {
+	var (
+		t                 biz.RefreshToken
+		usedAt, revokedAt sql.NullTime
+	)
+	err := r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = ?`+r.data.ForUpdate(), tokenHash,
+	).Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &usedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token: %w", err)
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return &t, nil
+}
+
+func (r *refreshTokenRepo) revokeFamily(ctx context.Context, familyID string) error {
+	if _, err := r.data.Conn(ctx).ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`, nowUTC(), familyID,
+	); err != nil {
+		return fmt.Errorf("revoke refresh token family %s: %w", familyID, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/services/user/internal/biz"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+// newTestUsecase returns a UserUsecase over a fresh SQLite database.
+func newTestUsecase(t *testing.T) *biz.UserUsecase {
+	t.Helper()
+	d, cleanup, err := NewData(&conf.Data{Database: &conf.Data_Database{
+		Driver: "sqlite",
+		Source: "file:" + filepath.Join(t.TempDir(), "user.db"),
+	}}, log.DefaultLogger)
+	if err != nil {
+		t.Fatalf("NewData: %v", err)
+	}
+	t.Cleanup(cleanup)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac := &conf.Auth{
+		Password: &conf.Auth_Password{BcryptCost: 4, Argon2Time: 1, Argon2MemoryKib: 1024},
+		Jwt:      &conf.Auth_JWT{PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))},
+	}
+	hasher, err := biz.NewPasswordHasher(ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := biz.NewTokenIssuer(ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return biz.NewUserUsecase(NewUserRepo(d, log.DefaultLogger), NewRefreshTokenRepo(d, log.DefaultLogger), hasher, issuer, log.DefaultLogger)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	uc := newTestUsecase(t)
+	if _, err := uc.CreateUser(ctx, &v1.CreateUserRequest{Username: "alice", Password: "correct9horse"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	login := func() string {
+		t.Helper()
+		resp, err := uc.Login(ctx, &v1.LoginRequest{Username: "alice", Password: "correct9horse"})
+		if err != nil {
+			t.Fatalf("Login: %v", err)
+		}
+		return resp.RefreshToken
+	}
+	refresh := func(token string) (string, error) {
+		resp, err := uc.RefreshToken(ctx, &v1.RefreshTokenRequest{RefreshToken: token})
+		return resp.GetRefreshToken(), err
+	}
+
+	t.Run("reuse revokes the family", func(t *testing.T) {
+		first := login()
+		other := login()
+		second, err := refresh(first)
+		if err != nil || second == "" || second == first {
+			t.Fatalf("RefreshToken: %q, %v", second, err)
+		}
+		third, err := refresh(second)
+		if err != nil {
+			t.Fatalf("RefreshToken of the rotated token: %v", err)
+		}
+
+		if _, err := refresh(first); !errors.Is(err, biz.ErrRefreshTokenReused) {
+			t.Fatalf("reused token: got %v, want ErrRefreshTokenReused", err)
+		}
+		if _, err := refresh(third); !errors.Is(err, biz.ErrInvalidRefreshToken) {
+			t.Fatalf("latest token of a revoked family: got %v, want ErrInvalidRefreshToken", err)
+		}
+		// Another login is another family and keeps working.
+		if _, err := refresh(other); err != nil {
+			t.Fatalf("token of another login: %v", err)
+		}
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		token := login()
+		rotated, err := refresh(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := uc.Logout(ctx, &v1.LogoutRequest{RefreshToken: rotated}); err != nil {
+			t.Fatalf("Logout: %v", err)
+		}
+		if _, err := refresh(rotated); !errors.Is(err, biz.ErrInvalidRefreshToken) {
+			t.Fatalf("refresh after logout: got %v, want ErrInvalidRefreshToken", err)
+		}
+		if _, err := uc.Logout(ctx, &v1.LogoutRequest{RefreshToken: "unknown"}); err != nil {
+			t.Fatalf("Logout with an unknown token: %v", err)
+		}
+	})
+
+	if _, err := refresh("not-a-token"); !errors.Is(err, biz.ErrInvalidRefreshToken) {
+		t.Fatalf("unknown token: got %v, want ErrInvalidRefreshToken", err)
+	}
+}
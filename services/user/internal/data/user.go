@@ -2,15 +2,30 @@ package data
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/pagination"
+	"github.com/reverny/kratos-mono/pkg/sqldb"
 	"github.com/reverny/kratos-mono/services/user/internal/biz"
 )
 
+// Defaults of a newly created user.
+const (
+	defaultRole   = auth.RoleUser
+	defaultStatus = "active"
+)
+
+const userColumns = `id, username, email, full_name, avatar_url, role, status, created_at, updated_at`
+
 type userRepo struct {
 	data *Data
 	log  *log.Helper
@@ -24,105 +39,203 @@ func NewUserRepo(data *Data, logger log.Logger) biz.UserRepo {
 	}
 }
 
-func (r *userRepo) CreateUser(ctx context.Context, req *v1.CreateUserRequest) (*v1.UserInfo, error) {
-	now := time.Now().Format(time.RFC3339)
-	user := &v1.UserInfo{
-		Id:        uuid.New().String(),
-		Username:  req.Username,
-		Email:     req.Email,
-		FullName:  req.FullName,
-		Role:      "user",
-		Status:    "active",
-		CreatedAt: now,
-		UpdatedAt: now,
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner, extra ...any) (*v1.UserInfo, error) {
+	var (
+		user                 v1.UserInfo
+		createdAt, updatedAt time.Time
+	)
+	dest := append([]any{
+		&user.Id, &user.Username, &user.Email, &user.FullName, &user.AvatarUrl,
+		&user.Role, &user.Status, &createdAt, &updatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
 	}
+	user.CreatedAt = createdAt.Format(time.RFC3339)
+	user.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return &user, nil
+}
 
-	// TODO: Hash password and save to database
-	r.log.Infof("User created: %s", user.Id)
+// nowUTC returns the current time at the precision stored by every supported driver.
+func nowUTC() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
 
-	return user, nil
+func (r *userRepo) CreateUser(ctx context.Context, req *v1.CreateUserRequest, passwordHash string) (*v1.UserInfo, error) {
+	id := uuid.New().String()
+	now := nowUTC()
+	_, err := r.data.Conn(ctx).ExecContext(ctx,
+		`INSERT INTO users (id, username, email, password_hash, full_name, avatar_url, role, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, '', ?, ?, ?, ?)`,
+		id, req.Username, req.Email, passwordHash, req.FullName, defaultRole, defaultStatus, now, now,
+	)
+	if sqldb.IsDuplicateKey(err) {
+		return nil, biz.ErrUsernameAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	r.log.Infof("User created: %s", id)
+	return r.GetUser(ctx, id)
 }
 
 func (r *userRepo) GetUser(ctx context.Context, id string) (*v1.UserInfo, error) {
-	// TODO: Get from database
-	user := &v1.UserInfo{
-		Id:        id,
-		Username:  "johndoe",
-		Email:     "john@example.com",
-		FullName:  "John Doe",
-		AvatarUrl: "https://example.com/avatar.jpg",
-		Role:      "user",
-		Status:    "active",
-		CreatedAt: time.Now().Format(time.RFC3339),
-		UpdatedAt: time.Now().Format(time.RFC3339),
+	user, err := scanUser(r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT `+userColumns+` FROM users WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user %s: %w", id, err)
 	}
-
 	return user, nil
 }
 
 func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*v1.UserInfo, string, error) {
-	// TODO: Get from database
-	user := &v1.UserInfo{
-		Id:        uuid.New().String(),
-		Username:  username,
-		Email:     "user@example.com",
-		FullName:  "Sample User",
-		Role:      "user",
-		Status:    "active",
-		CreatedAt: time.Now().Format(time.RFC3339),
-		UpdatedAt: time.Now().Format(time.RFC3339),
+	var passwordHash string
+	user, err := scanUser(r.data.Conn(ctx).QueryRowContext(ctx,
+		`SELECT `+userColumns+`, password_hash FROM users WHERE username = ?`, username), &passwordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("get user by username %s: %w", username, err)
 	}
-
-	passwordHash := "$2a$10$hashedpassword"
-
 	return user, passwordHash, nil
 }
 
-func (r *userRepo) ListUsers(ctx context.Context, page, pageSize int32, role, status string) ([]*v1.UserInfo, int32, error) {
-	// TODO: Get from database with pagination and filters
-	users := []*v1.UserInfo{
-		{
-			Id:        "1",
-			Username:  "admin",
-			Email:     "admin@example.com",
-			FullName:  "Admin User",
-			Role:      "admin",
-			Status:    "active",
-			CreatedAt: time.Now().Format(time.RFC3339),
-			UpdatedAt: time.Now().Format(time.RFC3339),
-		},
-		{
-			Id:        "2",
-			Username:  "user1",
-			Email:     "user1@example.com",
-			FullName:  "User One",
-			Role:      "user",
-			Status:    "active",
-			CreatedAt: time.Now().Format(time.RFC3339),
-			UpdatedAt: time.Now().Format(time.RFC3339),
-		},
-	}
-
-	return users, int32(len(users)), nil
+func (r *userRepo) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	res, err := r.data.Conn(ctx).ExecContext(ctx,
+		`UPDATE users SET password_hash = ?, updated_at = ? WHERE id = ?`, passwordHash, nowUTC(), id)
+	if err != nil {
+		return fmt.Errorf("update password of user %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return biz.ErrUserNotFound
+	}
+	return nil
 }
 
-func (r *userRepo) UpdateUser(ctx context.Context, req *v1.UpdateUserRequest) (*v1.UserInfo, error) {
-	// TODO: Update in database
-	user := &v1.UserInfo{
-		Id:        req.Id,
-		Email:     req.Email,
-		FullName:  req.FullName,
-		AvatarUrl: req.AvatarUrl,
-		UpdatedAt: time.Now().Format(time.RFC3339),
+func (r *userRepo) ListUsers(ctx context.Context, page, pageSize int32, role, status, pageToken string) ([]*v1.UserInfo, int32, string, error) {
+	var (
+		conds []string
+		args  []any
+		total int32
+	)
+	if role != "" {
+		conds, args = append(conds, `role = ?`), append(args, role)
+	}
+	if status != "" {
+		conds, args = append(conds, `status = ?`), append(args, status)
 	}
 
-	r.log.Infof("User updated: %s", user.Id)
+	// Keyset by id: a page token carries the last id returned. Offset
+	// paging also counts the total.
+	filter := pagination.Fingerprint(role, status)
+	if pageToken != "" {
+		cursor, err := pagination.Decode(pageToken, filter)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		conds, args = append(conds, `id > ?`), append(args, cursor.ID)
+	}
+	var where string
+	if len(conds) > 0 {
+		where = ` WHERE ` + strings.Join(conds, ` AND `)
+	}
+	if pageToken == "" {
+		if err := r.data.Conn(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+			return nil, 0, "", fmt.Errorf("count users: %w", err)
+		}
+	}
 
-	return user, nil
+	// Fetch one extra row to learn whether another page follows.
+	query := `SELECT ` + userColumns + ` FROM users` + where + ` ORDER BY id LIMIT ?`
+	args = append(args, pageSize+1)
+	if pageToken == "" {
+		query += ` OFFSET ?`
+		args = append(args, (page-1)*pageSize)
+	}
+	rows, err := r.data.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*v1.UserInfo, 0, pageSize)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, "", fmt.Errorf("list users: %w", err)
+	}
+
+	if len(users) <= int(pageSize) {
+		return users, total, "", nil
+	}
+	users = users[:pageSize]
+	next := pagination.Encode(&pagination.Cursor{ID: users[len(users)-1].Id, Filter: filter})
+	return users, total, next, nil
+}
+
+func (r *userRepo) UpdateUser(ctx context.Context, req *v1.UpdateUserRequest) (*v1.UserInfo, error) {
+	sets := []string{`updated_at = ?`}
+	args := []any{nowUTC()}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case biz.UserFieldEmail:
+			sets, args = append(sets, `email = ?`), append(args, req.Email)
+		case biz.UserFieldFullName:
+			sets, args = append(sets, `full_name = ?`), append(args, req.FullName)
+		case biz.UserFieldAvatarURL:
+			sets, args = append(sets, `avatar_url = ?`), append(args, req.AvatarUrl)
+		}
+	}
+	// MySQL reports rows left unchanged as unaffected, so a missing id is
+	// detected by the read that follows rather than by RowsAffected.
+	if _, err := r.data.Conn(ctx).ExecContext(ctx,
+		`UPDATE users SET `+strings.Join(sets, `, `)+` WHERE id = ?`, append(args, req.Id)...,
+	); err != nil {
+		return nil, fmt.Errorf("update user %s: %w", req.Id, err)
+	}
+
+	r.log.Infof("User updated: %s", req.Id)
+	return r.GetUser(ctx, req.Id)
 }
 
 func (r *userRepo) DeleteUser(ctx context.Context, id string) error {
-	// TODO: Delete from database
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		res, err := r.data.Conn(ctx).ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("delete user %s: %w", id, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return biz.ErrUserNotFound
+		}
+		// The user's sessions end with it.
+		if _, err := r.data.Conn(ctx).ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, id); err != nil {
+			return fmt.Errorf("delete refresh tokens of user %s: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
 	r.log.Infof("User deleted: %s", id)
 	return nil
 }
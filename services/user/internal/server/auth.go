@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/go-kratos/kratos/v2/middleware"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/services/user/internal/biz"
+	"github.com/reverny/kratos-mono/services/user/internal/conf"
+)
+
+// publicOperations are reachable without an access token: signing up and
+// the operations that obtain or give up tokens.
+var publicOperations = []string{
+	v1.User_CreateUser_FullMethodName,
+	v1.User_Login_FullMethodName,
+	v1.User_RefreshToken_FullMethodName,
+	v1.User_Logout_FullMethodName,
+}
+
+// policy holds the roles required by the RPCs annotated with
+// (api.auth.required_role) in user.proto.
+var policy = auth.MustPolicy(v1.File_user_v1_user_proto)
+
+// NewVerifier returns the access token verifier shared by the gRPC and HTTP
+// servers. Tokens are checked against the issuer's own keys, so no JWKS
+// fetch is needed.
+func NewVerifier(c *conf.Server, issuer *biz.TokenIssuer) *auth.Verifier {
+	ac := c.GetAuth()
+	return auth.NewVerifier(issuer.Keyfunc, ac.GetIssuer(), ac.GetAudience())
+}
+
+func authMiddleware(v *auth.Verifier) middleware.Middleware {
+	return auth.Middleware(v, policy, auth.WithPublicOperations(publicOperations...))
+}
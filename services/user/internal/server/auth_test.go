@@ -0,0 +1,24 @@
+package server
+
+import (
+	"testing"
+
+	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
+	"github.com/reverny/kratos-mono/pkg/auth/authztest"
+)
+
+func TestPolicy(t *testing.T) {
+	authztest.Run(t, policy, []authztest.Case{
+		{Operation: v1.User_CreateUser_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.User_GetUser_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.User_ListUsers_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.User_ListUsers_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.User_UpdateUser_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.User_DeleteUser_FullMethodName, Role: auth.RoleUser, Allowed: false},
+		{Operation: v1.User_DeleteUser_FullMethodName, Role: auth.RoleAdmin, Allowed: true},
+		{Operation: v1.User_Login_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.User_RefreshToken_FullMethodName, Role: auth.RoleUser, Allowed: true},
+		{Operation: v1.User_Logout_FullMethodName, Role: auth.RoleUser, Allowed: true},
+	})
+}
@@ -6,15 +6,17 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
 	"github.com/reverny/kratos-mono/services/user/internal/conf"
 	"github.com/reverny/kratos-mono/services/user/internal/service"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, userService *service.UserService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, verifier *auth.Verifier, userService *service.UserService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			authMiddleware(verifier),
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -9,6 +9,7 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 
 	v1 "github.com/reverny/kratos-mono/gen/go/api/user/v1"
+	"github.com/reverny/kratos-mono/pkg/auth"
 	"github.com/reverny/kratos-mono/services/user/internal/conf"
 	"github.com/reverny/kratos-mono/services/user/internal/service"
 )
@@ -17,10 +18,11 @@ import (
 var swaggerHTML []byte
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, userService *service.UserService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, verifier *auth.Verifier, userService *service.UserService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(),
+			authMiddleware(verifier),
 		),
 	}
 	if c.Http.Network != "" {
@@ -34,6 +36,10 @@ func NewHTTPServer(c *conf.Server, userService *service.UserService, logger log.
 	}
 	srv := http.NewServer(opts...)
 	v1.RegisterUserHTTPServer(srv, userService)
+
+	// Token signing keys for the services that verify access tokens
+	r := srv.Route("/")
+	r.GET(auth.JWKSPath, userService.JWKSHTTP)
 	
 	// Serve Swagger UI
 	srv.HandleFunc("/docs", func(w nethttp.ResponseWriter, r *nethttp.Request) {
@@ -0,0 +1,21 @@
+package service
+
+import (
+	nethttp "net/http"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// jwksMaxAge is how long clients may cache the key set.
+const jwksMaxAge = "public, max-age=300"
+
+// JWKSHTTP handles GET /.well-known/jwks.json, the public keys other services
+// verify access tokens with. It is public, so it skips the middleware chain.
+func (s *UserService) JWKSHTTP(ctx http.Context) error {
+	set, err := s.uc.JWKS()
+	if err != nil {
+		return err
+	}
+	ctx.Response().Header().Set("Cache-Control", jwksMaxAge)
+	return ctx.JSON(nethttp.StatusOK, set)
+}
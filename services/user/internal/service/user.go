@@ -42,3 +42,11 @@ func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserRequest)
 func (s *UserService) Login(ctx context.Context, req *v1.LoginRequest) (*v1.LoginResponse, error) {
 	return s.uc.Login(ctx, req)
 }
+
+func (s *UserService) RefreshToken(ctx context.Context, req *v1.RefreshTokenRequest) (*v1.RefreshTokenResponse, error) {
+	return s.uc.RefreshToken(ctx, req)
+}
+
+func (s *UserService) Logout(ctx context.Context, req *v1.LogoutRequest) (*emptypb.Empty, error) {
+	return s.uc.Logout(ctx, req)
+}